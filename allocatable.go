@@ -0,0 +1,97 @@
+package skewer
+
+import "math"
+
+// OverheadTier reserves Fraction of the next Size units of a resource.
+// A Size of zero covers all remaining capacity.
+type OverheadTier struct {
+	Size     float64
+	Fraction float64
+}
+
+// OverheadModel describes the capacity reserved on a vm for the
+// operating system and runtime, similar to kube-reserved. CPU tiers are
+// sized in cores and memory tiers in GiB. Fixed reservations are
+// subtracted after tiered ones.
+type OverheadModel struct {
+	CPUTiers           []OverheadTier
+	MemoryTiers        []OverheadTier
+	FixedCPUMillicores int64
+	FixedMemoryMiB     int64
+}
+
+// KubeReservedOverhead returns the widely used tiered kube-reserved
+// model: 6% of the first core, 1% of the next core, 0.5% of the next
+// two cores and 0.25% of any cores above four; 25% of the first 4 GiB
+// of memory, 20% of the next 4 GiB, 10% of the next 8 GiB, 6% of the
+// next 112 GiB and 2% of any memory above 128 GiB, plus a 100 MiB
+// eviction threshold.
+func KubeReservedOverhead() OverheadModel {
+	return OverheadModel{
+		CPUTiers: []OverheadTier{
+			{Size: 1, Fraction: 0.06},
+			{Size: 1, Fraction: 0.01},
+			{Size: 2, Fraction: 0.005},
+			{Fraction: 0.0025},
+		},
+		MemoryTiers: []OverheadTier{
+			{Size: 4, Fraction: 0.25},
+			{Size: 4, Fraction: 0.2},
+			{Size: 8, Fraction: 0.1},
+			{Size: 112, Fraction: 0.06},
+			{Fraction: 0.02},
+		},
+		FixedMemoryMiB: 100,
+	}
+}
+
+// reserved returns the amount reserved by the tiers for capacity.
+func reserved(tiers []OverheadTier, capacity float64) float64 {
+	var total float64
+	remaining := capacity
+	for _, tier := range tiers {
+		if remaining <= 0 {
+			break
+		}
+		size := remaining
+		if tier.Size > 0 && tier.Size < remaining {
+			size = tier.Size
+		}
+		total += size * tier.Fraction
+		remaining -= size
+	}
+	return total
+}
+
+// AllocatableCPU returns the millicores of cpu left for workloads after
+// subtracting the overhead of the model. It errors if the vCPU
+// capability cannot be read, and never returns less than zero.
+func (s *SKU) AllocatableCPU(model OverheadModel) (int64, error) {
+	vcpu, err := s.VCPU()
+	if err != nil {
+		return -1, err
+	}
+	capacity := vcpu * millicoresPerCore
+	overhead := int64(math.Ceil(reserved(model.CPUTiers, float64(vcpu))*millicoresPerCore)) + model.FixedCPUMillicores
+	return nonNegative(capacity - overhead), nil
+}
+
+// AllocatableMemory returns the MiB of memory left for workloads after
+// subtracting the overhead of the model. It errors if the memory
+// capability cannot be read, and never returns less than zero.
+func (s *SKU) AllocatableMemory(model OverheadModel) (int64, error) {
+	capacity, err := s.MemoryMiB()
+	if err != nil {
+		return -1, err
+	}
+	overhead := int64(math.Ceil(reserved(model.MemoryTiers, float64(capacity)/mebibytesPerGiB)*mebibytesPerGiB)) +
+		model.FixedMemoryMiB
+	return nonNegative(capacity - overhead), nil
+}
+
+func nonNegative(value int64) int64 {
+	if value < 0 {
+		return 0
+	}
+	return value
+}
@@ -0,0 +1,67 @@
+package skewer
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-03-01/compute" //nolint:staticcheck
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_SKU_Allocatable(t *testing.T) {
+	newSKU := func(vcpu, memory string) SKU {
+		return SKU{
+			Capabilities: &[]compute.ResourceSkuCapabilities{
+				{Name: to.StringPtr(VCPUs), Value: to.StringPtr(vcpu)},
+				{Name: to.StringPtr(MemoryGB), Value: to.StringPtr(memory)},
+			},
+		}
+	}
+
+	cases := map[string]struct {
+		sku          SKU
+		model        OverheadModel
+		expectCPU    int64
+		expectMemory int64
+	}{
+		"should return raw capacity without overhead": {
+			sku:          newSKU("4", "16"),
+			expectCPU:    4000,
+			expectMemory: 16384,
+		},
+		"should apply kube reserved tiers": {
+			sku:   newSKU("4", "16"),
+			model: KubeReservedOverhead(),
+			// 60 + 10 + 10 millicores
+			expectCPU: 3920,
+			// 1024 + 819.2 + 819.2 MiB rounded up, plus 100 MiB
+			expectMemory: 16384 - 2663 - 100,
+		},
+		"should not return negative capacity": {
+			sku:          newSKU("1", "0.5"),
+			model:        OverheadModel{FixedCPUMillicores: 2000, FixedMemoryMiB: 1024},
+			expectCPU:    0,
+			expectMemory: 0,
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			cpu, err := tc.sku.AllocatableCPU(tc.model)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.expectCPU, cpu); diff != "" {
+				t.Error(diff)
+			}
+			memory, err := tc.sku.AllocatableMemory(tc.model)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.expectMemory, memory); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package skewer
+
+// AuditReport summarizes the health of a sku catalog. It is intended to
+// be serialized, for example as JSON, by tooling tracking the effective
+// catalog of a subscription.
+type AuditReport struct {
+	// SKUs counts skus by resource type.
+	SKUs map[string]int `json:"skus"`
+	// Anomalies lists data issues, as reported by WithDiagnostics.
+	Anomalies []Anomaly `json:"anomalies"`
+	// UnknownCapabilities lists capability names skewer does not
+	// document, see DescribeCapability.
+	UnknownCapabilities []string `json:"unknownCapabilities"`
+	// MissingZones lists virtual machine skus without availability
+	// zones in a location where other virtual machine skus have zones.
+	MissingZones []string `json:"missingZones"`
+	// RestrictedFamilies lists families with at least one sku
+	// restricted in its location.
+	RestrictedFamilies []string `json:"restrictedFamilies"`
+}
+
+// Audit inspects skus and reports catalog wide integrity issues. Names
+// in the report are sorted and unique.
+func Audit(skus []SKU) AuditReport {
+	report := AuditReport{SKUs: map[string]int{}}
+
+	diagnostics := &warnOnce{
+		sink: func(anomaly Anomaly) { report.Anomalies = append(report.Anomalies, anomaly) },
+	}
+	diagnostics.diagnose(skus)
+
+	unknown := map[string]bool{}
+	restricted := map[string]bool{}
+	zonal := map[string]bool{}
+	var nonZonal []*SKU
+	for i := range skus {
+		sku := &skus[i]
+		report.SKUs[sku.GetResourceType()]++
+
+		if sku.Capabilities != nil {
+			for _, capability := range *sku.Capabilities {
+				if capability.Name != nil && DescribeCapability(*capability.Name).Type == CapabilityTypeUnknown {
+					unknown[*capability.Name] = true
+				}
+			}
+		}
+
+		location, err := sku.GetLocation()
+		if err != nil {
+			continue
+		}
+		if sku.IsRestricted(location) {
+			restricted[sku.GetFamilyName()] = true
+		}
+		if !sku.IsResourceType(VirtualMachines) {
+			continue
+		}
+		if len(sku.locationZones(location)) > 0 {
+			zonal[normalizeLocation(location)] = true
+		} else {
+			nonZonal = append(nonZonal, sku)
+		}
+	}
+
+	missing := map[string]bool{}
+	for _, sku := range nonZonal {
+		location, _ := sku.GetLocation()
+		if zonal[normalizeLocation(location)] {
+			missing[sku.GetName()] = true
+		}
+	}
+
+	report.UnknownCapabilities = sortedKeys(unknown)
+	report.MissingZones = sortedKeys(missing)
+	report.RestrictedFamilies = sortedKeys(restricted)
+
+	return report
+}
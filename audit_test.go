@@ -0,0 +1,81 @@
+package skewer
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-03-01/compute" //nolint:staticcheck
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_Audit(t *testing.T) {
+	dataWrapper, err := newDataWrapper("./testdata/eastus.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	zonal := SKU{
+		Name:         to.StringPtr("zonal"),
+		ResourceType: to.StringPtr(VirtualMachines),
+		Locations:    &[]string{"westus2"},
+		LocationInfo: &[]compute.ResourceSkuLocationInfo{
+			{Location: to.StringPtr("westus2"), Zones: &[]string{"1"}},
+		},
+		Capabilities: &[]compute.ResourceSkuCapabilities{
+			{Name: to.StringPtr("FancyNewCapability"), Value: to.StringPtr("True")},
+			{Name: to.StringPtr(VCPUs), Value: to.StringPtr("many")},
+		},
+	}
+	nonZonal := SKU{
+		Name:         to.StringPtr("nonzonal"),
+		ResourceType: to.StringPtr(VirtualMachines),
+		Locations:    &[]string{"westus2"},
+	}
+	disk := SKU{
+		Name:         to.StringPtr("disk"),
+		ResourceType: to.StringPtr(Disks),
+		Locations:    &[]string{"westus2"},
+	}
+
+	_, parseErr := parseQuantity(CapabilityTypeInteger, VCPUs, "many")
+
+	cases := map[string]struct {
+		skus   []SKU
+		expect AuditReport
+	}{
+		"empty catalog should be healthy": {
+			expect: AuditReport{SKUs: map[string]int{}},
+		},
+		"should report restricted families": {
+			skus: Wrap(dataWrapper.Value),
+			expect: AuditReport{
+				SKUs:               map[string]int{VirtualMachines: 4},
+				RestrictedFamilies: []string{"standardDv2PromoFamily"},
+			},
+		},
+		"should report unknown capabilities, anomalies and missing zones": {
+			skus: []SKU{zonal, nonZonal, disk},
+			expect: AuditReport{
+				SKUs: map[string]int{VirtualMachines: 2, Disks: 1},
+				Anomalies: []Anomaly{
+					{
+						Kind:   AnomalyUnparsableValue,
+						SKU:    "zonal",
+						Detail: parseErr.Error(),
+					},
+				},
+				UnknownCapabilities: []string{"FancyNewCapability"},
+				MissingZones:        []string{"nonzonal"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.expect, Audit(tc.skus)); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
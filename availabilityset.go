@@ -0,0 +1,30 @@
+package skewer
+
+import "context"
+
+// maxPlatformUpdateDomainCount is the maximum number of update domains
+// of an availability set, which is the same in every region.
+// See https://learn.microsoft.com/en-us/azure/virtual-machines/availability-set-overview
+const maxPlatformUpdateDomainCount = 20
+
+// MaxFaultDomains returns the maximum number of fault domains of an
+// availability set sku in its location.
+func (s *SKU) MaxFaultDomains() (int64, error) {
+	return s.GetCapabilityIntegerQuantity(CapabilityMaximumPlatformFaultDomainCount)
+}
+
+// MaxUpdateDomains returns the maximum number of update domains of an
+// availability set sku, or 0 for other resource types. The API does not
+// report it since it does not vary by region.
+func (s *SKU) MaxUpdateDomains() int64 {
+	if !s.IsResourceType(AvailabilitySets) {
+		return 0
+	}
+	return maxPlatformUpdateDomainCount
+}
+
+// GetAvailabilitySets returns the list of all availability set skus,
+// e.g. "Aligned" and "Classic", in a given azure location.
+func (c *Cache) GetAvailabilitySets(ctx context.Context) []SKU {
+	return c.ofType(ctx, AvailabilitySets)
+}
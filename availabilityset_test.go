@@ -0,0 +1,54 @@
+package skewer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-03-01/compute" //nolint:staticcheck
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_AvailabilitySets(t *testing.T) {
+	aligned := compute.ResourceSku{
+		ResourceType: to.StringPtr(AvailabilitySets),
+		Name:         to.StringPtr("Aligned"),
+		Locations:    &[]string{"eastus"},
+		Capabilities: &[]compute.ResourceSkuCapabilities{
+			{Name: to.StringPtr(CapabilityMaximumPlatformFaultDomainCount), Value: to.StringPtr("3")},
+		},
+	}
+	vm := compute.ResourceSku{
+		ResourceType: to.StringPtr(VirtualMachines),
+		Name:         to.StringPtr("Standard_D4s_v3"),
+		Locations:    &[]string{"eastus"},
+	}
+
+	cache, err := NewStaticCache(Wrap([]compute.ResourceSku{aligned, vm}), WithLocation("eastus"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sets := cache.GetAvailabilitySets(context.Background())
+	if len(sets) != 1 {
+		t.Fatalf("expected 1 availability set sku, got %d", len(sets))
+	}
+
+	faultDomains, err := sets[0].MaxFaultDomains()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(int64(3), faultDomains); diff != "" {
+		t.Error(diff)
+	}
+	if diff := cmp.Diff(int64(20), sets[0].MaxUpdateDomains()); diff != "" {
+		t.Error(diff)
+	}
+
+	other := SKU(vm)
+	if _, err := other.MaxFaultDomains(); err == nil {
+		t.Error("expected virtual machine sku to have no fault domain count")
+	}
+	if diff := cmp.Diff(int64(0), other.MaxUpdateDomains()); diff != "" {
+		t.Error(diff)
+	}
+}
@@ -0,0 +1,110 @@
+package skewer
+
+import (
+	"context"
+	"math/bits"
+	"strings"
+)
+
+// bitmapCapabilities are the boolean capabilities indexed by a cache.
+var bitmapCapabilities = []string{
+	EphemeralOSDisk,
+	EncryptionAtHost,
+	AcceleratedNetworking,
+	CapabilityPremiumIO,
+	CapabilityLowPriorityCapable,
+	CapabilityRdmaEnabled,
+	CapabilityCapacityReservationSupported,
+	CapabilityMemoryPreservingMaintenanceSupported,
+}
+
+// wordBits is the number of sku indexes held by each word of a bitset.
+const wordBits = 64
+
+// bitset is a set of sku indexes of a cache.
+type bitset []uint64
+
+func newBitset(size int) bitset {
+	return make(bitset, (size+wordBits-1)/wordBits)
+}
+
+func (b bitset) set(i int) {
+	b[i/wordBits] |= 1 << (uint(i) % wordBits)
+}
+
+// and intersects b with other, in place.
+func (b bitset) and(other bitset) {
+	for i := range b {
+		b[i] &= other[i]
+	}
+}
+
+// each calls fn with every index in the set, in increasing order.
+func (b bitset) each(fn func(i int)) {
+	for word, value := range b {
+		for value != 0 {
+			offset := bits.TrailingZeros64(value)
+			fn(word*wordBits + offset)
+			value &= value - 1
+		}
+	}
+}
+
+// capabilityBitmaps maps lower case capability names to the set of skus
+// supporting them.
+type capabilityBitmaps map[string]bitset
+
+func newCapabilityBitmaps(skus []SKU) capabilityBitmaps {
+	bitmaps := make(capabilityBitmaps, len(bitmapCapabilities))
+	for _, name := range bitmapCapabilities {
+		bitmaps[strings.ToLower(name)] = newBitset(len(skus))
+	}
+	for i := range skus {
+		if skus[i].Capabilities == nil {
+			continue
+		}
+		for _, capability := range *skus[i].Capabilities {
+			if capability.Name == nil || capability.Value == nil {
+				continue
+			}
+			set, ok := bitmaps[strings.ToLower(*capability.Name)]
+			if ok && strings.EqualFold(*capability.Value, string(CapabilitySupported)) {
+				set.set(i)
+			}
+		}
+	}
+	return bitmaps
+}
+
+// ListSupporting returns the skus supporting every boolean capability,
+// as HasCapability does, and matching all filters. Common capabilities
+// are evaluated with precomputed bitmaps rather than scanning each sku,
+// which speeds up searches with many constraints.
+func (c *Cache) ListSupporting(ctx context.Context, capabilities []string, filters ...FilterFn) []SKU {
+	data, index := c.view(ctx)
+	bitmaps := index.capabilityBitmaps(data)
+	candidates := newBitset(len(data))
+	for i := range candidates {
+		candidates[i] = ^uint64(0)
+	}
+	for _, name := range capabilities {
+		if set, ok := bitmaps[strings.ToLower(name)]; ok {
+			candidates.and(set)
+			continue
+		}
+		name := name
+		filters = append([]FilterFn{func(s *SKU) bool { return s.HasCapability(name) }}, filters...)
+	}
+
+	if data == nil {
+		return nil
+	}
+
+	result := make([]SKU, 0)
+	candidates.each(func(i int) {
+		if i < len(data) && All(&data[i], filters) {
+			result = append(result, data[i])
+		}
+	})
+	return result
+}
@@ -0,0 +1,68 @@
+package skewer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_Cache_ListSupporting(t *testing.T) {
+	dataWrapper, err := newDataWrapper("./testdata/eastus.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache, err := NewStaticCache(Wrap(dataWrapper.Value))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]struct {
+		capabilities []string
+		filters      []FilterFn
+	}{
+		"no capabilities should list everything": {},
+		"indexed capabilities": {
+			capabilities: []string{AcceleratedNetworking, "premiumio"},
+		},
+		"unindexed capabilities": {
+			capabilities: []string{AcceleratedNetworking, CapabilityMemoryPreservingMaintenanceSupported, "HyperVGenerations"},
+		},
+		"capabilities with filters": {
+			capabilities: []string{CapabilityLowPriorityCapable},
+			filters:      []FilterFn{NameFilter("standard_d2_v2")},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			filters := append([]FilterFn(nil), tc.filters...)
+			for _, capability := range tc.capabilities {
+				capability := capability
+				filters = append(filters, func(s *SKU) bool { return s.HasCapability(capability) })
+			}
+			expect := cache.List(context.Background(), filters...)
+			got := cache.ListSupporting(context.Background(), tc.capabilities, tc.filters...)
+			if diff := cmp.Diff(expect, got); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func Test_bitset(t *testing.T) {
+	a, b := newBitset(130), newBitset(130)
+	for _, i := range []int{0, 63, 64, 129} {
+		a.set(i)
+	}
+	for _, i := range []int{63, 100, 129} {
+		b.set(i)
+	}
+	a.and(b)
+	var got []int
+	a.each(func(i int) { got = append(got, i) })
+	if diff := cmp.Diff([]int{63, 129}, got); diff != "" {
+		t.Error(diff)
+	}
+}
@@ -4,34 +4,91 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-03-01/compute" //nolint:staticcheck
 )
 
 // Config contains configuration options for a cache.
 type Config struct {
 	location                 string
+	locations                []string
 	includeExtendedLocations string
 	filter                   string
 	client                   client
-}
-
-// Cache stores a list of known skus, possibly fetched with a provided client
+	includeRestricted        bool
+	diagnostics              *warnOnce
+	sources                  []Source
+	featureClient            FeatureClient
+	featureRequirements      []FeatureRequirement
+	overlay                  map[string]Annotations
+	queryTTL                 time.Duration
+	excludePromo             bool
+	ttl                      time.Duration
+	clock                    func() time.Time
+}
+
+// Cache stores a list of known skus, possibly fetched with a provided
+// client. It is safe for concurrent use: a refresh lists and indexes new
+// data before swapping it in, so readers are never blocked on the
+// client and always observe a consistent list.
 type Cache struct {
 	config *Config
-	data   []SKU
+	// refreshMu serializes refreshes, so data is replaced in order.
+	refreshMu sync.Mutex
+	// mu guards data and its index, which are replaced but never
+	// modified, the time they were loaded at and their generation,
+	// which counts replacements.
+	mu         sync.RWMutex
+	data       []SKU
+	index      *skuIndex
+	loadedAt   time.Time
+	generation uint64
+	queries    *queryCache
 }
 
 // Option describes functional options to customize the listing behavior of the cache.
 type Option func(c *Config) (*Config, error)
 
-// WithLocation is a functional option to filter skus by location
+// WithLocation is a functional option to filter skus by location. Both
+// display names like "East US" and names like "eastus" are accepted.
 func WithLocation(location string) Option {
 	return func(c *Config) (*Config, error) {
-		c.location = location
-		c.filter = fmt.Sprintf("location eq '%s'", location)
+		c.location = normalizeLocation(location)
+		c.locations = nil
+		c.filter = locationFilter(c.location)
+		return c, nil
+	}
+}
+
+// WithLocations is a functional option to populate the cache with the
+// skus of several locations, listed concurrently with one request per
+// location. A single location is the same as WithLocation, and none
+// lists all locations. Query the skus of one location with the InLocation
+// methods, e.g. GetAvailableVirtualMachinesInLocation.
+func WithLocations(locations ...string) Option {
+	return func(c *Config) (*Config, error) {
+		if len(locations) == 1 {
+			return WithLocation(locations[0])(c)
+		}
+		c.location, c.filter, c.locations = "", "", nil
+		seen := map[string]bool{}
+		for _, location := range locations {
+			location = normalizeLocation(location)
+			if !seen[location] {
+				seen[location] = true
+				c.locations = append(c.locations, location)
+			}
+		}
 		return c, nil
 	}
 }
 
+func locationFilter(location string) string {
+	return fmt.Sprintf("location eq '%s'", location)
+}
+
 // WithExtendedLocations is a functional option to include extended locations
 func WithExtendedLocations() Option {
 	return func(c *Config) (*Config, error) {
@@ -40,6 +97,45 @@ func WithExtendedLocations() Option {
 	}
 }
 
+// WithoutPromo is a functional option to drop promotional skus from the
+// cache, which look cheaper than their regular sizes but are commonly
+// restricted.
+func WithoutPromo() Option {
+	return func(c *Config) (*Config, error) {
+		c.excludePromo = true
+		return c, nil
+	}
+}
+
+// WithTTL is a functional option to refresh the cache on the first
+// access after its data is older than ttl, so long lived processes do
+// not serve stale availability. A failed refresh keeps serving the
+// previous data and is retried on the next access. It has no effect on
+// caches which cannot refresh, such as static caches.
+func WithTTL(ttl time.Duration) Option {
+	return func(c *Config) (*Config, error) {
+		c.ttl = ttl
+		return c, nil
+	}
+}
+
+// now returns the current time of the config's clock.
+func (c *Config) now() time.Time {
+	if c.clock != nil {
+		return c.clock()
+	}
+	return time.Now()
+}
+
+// WithIncludeRestricted is a functional option to include restricted
+// skus and zones in the results of availability queries.
+func WithIncludeRestricted() Option {
+	return func(c *Config) (*Config, error) {
+		c.includeRestricted = true
+		return c, nil
+	}
+}
+
 // ErrClientNil will be returned when a user attempts to create a cache
 // without a client and use it.
 type ErrClientNil struct {
@@ -140,24 +236,168 @@ func NewStaticCache(data []SKU, opts ...Option) (*Cache, error) {
 	}
 
 	c := &Cache{
-		data:   data,
 		config: config,
 	}
 
+	c.setData(append([]SKU(nil), data...))
+
 	return c, nil
 }
 
+// Refresh re-fetches the data of the cache from its client, or from
+// its sources for a layered cache. It errors for caches without either.
+func (c *Cache) Refresh(ctx context.Context) error {
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+	return c.refreshLocked(ctx)
+}
+
+// refreshLocked refreshes the cache, with refreshMu held.
+func (c *Cache) refreshLocked(ctx context.Context) error {
+	if len(c.config.sources) > 0 {
+		return c.refreshLayers(ctx)
+	}
+	if c.config.client == nil {
+		return &ErrClientNil{}
+	}
+	return c.refresh(ctx)
+}
+
 func (c *Cache) refresh(ctx context.Context) error {
-	data, err := c.config.client.List(ctx, c.config.filter, c.config.includeExtendedLocations)
+	data, err := c.config.list(ctx, c.config.client)
 	if err != nil {
 		return err
 	}
 
-	c.data = Wrap(data)
+	c.load(data)
 
 	return nil
 }
 
+// list lists skus with the client for the configured location, or
+// concurrently for each location of WithLocations, returning the error
+// of the first location which failed.
+func (c *Config) list(ctx context.Context, lister client) ([]compute.ResourceSku, error) {
+	if len(c.locations) == 0 {
+		return lister.List(ctx, c.filter, c.includeExtendedLocations)
+	}
+
+	results := make([][]compute.ResourceSku, len(c.locations))
+	errs := make([]error, len(c.locations))
+	var wg sync.WaitGroup
+	for i := range c.locations {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = lister.List(ctx, locationFilter(c.locations[i]), c.includeExtendedLocations)
+		}(i)
+	}
+	wg.Wait()
+
+	var data []compute.ResourceSku
+	for i := range results {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		data = append(data, results[i]...)
+	}
+	return data, nil
+}
+
+// load replaces the data of the cache.
+func (c *Cache) load(data []compute.ResourceSku) {
+	c.setData(Wrap(data))
+}
+
+// setData replaces the data of the cache, sorting it in canonical order
+// and indexing it.
+func (c *Cache) setData(data []SKU) {
+	if c.config.excludePromo {
+		data = Filter(data, func(s *SKU) bool { return !s.IsPromo() })
+	}
+	sortSKUs(data)
+	index := newSKUIndex(data)
+
+	c.mu.Lock()
+	c.data = data
+	c.index = index
+	c.loadedAt = c.config.now()
+	c.generation++
+	generation := c.generation
+	c.mu.Unlock()
+
+	// The query cache is created by the first call, before the cache is
+	// returned to callers.
+	if c.queries == nil && c.config.queryTTL > 0 {
+		c.queries = newQueryCache(c.config.queryTTL, c.config.now)
+	}
+	c.queries.reset(generation)
+	c.config.diagnostics.diagnose(data)
+}
+
+// view returns the data of the cache and its index, refreshing them
+// first when they expired. Both are replaced rather than modified by
+// refreshes, so callers may keep using them after the lock is released.
+func (c *Cache) view(ctx context.Context) ([]SKU, *skuIndex) {
+	c.refreshExpired(ctx)
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.data, c.index
+}
+
+// generationView returns the data of the cache, see view, and its
+// generation.
+func (c *Cache) generationView(ctx context.Context) ([]SKU, uint64) {
+	c.refreshExpired(ctx)
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.data, c.generation
+}
+
+// ofType returns the skus of the resource type matching all filters,
+// from the partition of the index.
+func (c *Cache) ofType(ctx context.Context, resourceType string, filters ...FilterFn) []SKU {
+	data, index := c.view(ctx)
+	return Filter(index.ofType(data, resourceType), filters...)
+}
+
+// skus returns the data of the cache, see view.
+func (c *Cache) skus(ctx context.Context) []SKU {
+	data, _ := c.view(ctx)
+	return data
+}
+
+// current returns the data of the cache without refreshing it, for
+// accessors without a context.
+func (c *Cache) current() []SKU {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.data
+}
+
+// refreshExpired refreshes a cache whose data is older than the ttl of
+// WithTTL. Accesses concurrent with a refresh do not wait for it, and
+// failures keep the previous data.
+func (c *Cache) refreshExpired(ctx context.Context) {
+	if c.config == nil || c.config.ttl <= 0 || (c.config.client == nil && len(c.config.sources) == 0) || !c.expired() {
+		return
+	}
+	if !c.refreshMu.TryLock() {
+		return
+	}
+	defer c.refreshMu.Unlock()
+	if c.expired() {
+		_ = c.refreshLocked(ctx)
+	}
+}
+
+// expired returns true when the data is older than the ttl.
+func (c *Cache) expired() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.config.now().Sub(c.loadedAt) >= c.config.ttl
+}
+
 // ErrMultipleSKUsMatch will be returned when multiple skus match a
 // fully qualified triple of resource type, location and name. This should usually not happen.
 type ErrMultipleSKUsMatch struct {
@@ -184,9 +424,9 @@ func (e *ErrSKUNotFound) Error() string {
 
 // Get returns the first matching resource of a given name and type in a location.
 func (c *Cache) Get(ctx context.Context, name, resourceType, location string) (SKU, error) {
-	filtered := Filter(c.data, []FilterFn{
+	data, index := c.view(ctx)
+	filtered := Filter(index.byName(data, name), []FilterFn{
 		ResourceTypeFilter(resourceType),
-		NameFilter(name),
 		LocationFilter(location),
 	}...)
 
@@ -211,12 +451,80 @@ func (c *Cache) Get(ctx context.Context, name, resourceType, location string) (S
 
 // List returns all resource types for this location.
 func (c *Cache) List(ctx context.Context, filters ...FilterFn) []SKU {
-	return Filter(c.data, filters...)
+	return Filter(c.skus(ctx), filters...)
+}
+
+// ListInLocation returns the skus listing the location and matching all
+// filters, looked up in an index rather than filtering the whole cache,
+// e.g. for caches of every region.
+func (c *Cache) ListInLocation(ctx context.Context, location string, filters ...FilterFn) []SKU {
+	data, index := c.view(ctx)
+	return Filter(index.byLocation(data, location), filters...)
+}
+
+// GetByFamily returns the skus of the family, e.g.
+// "standardDSv3Family" as used by compute quotas, and matching all
+// filters, looked up in an index rather than filtering the whole cache.
+func (c *Cache) GetByFamily(ctx context.Context, family string, filters ...FilterFn) []SKU {
+	data, index := c.view(ctx)
+	return Filter(index.byFamily(data, family), filters...)
+}
+
+// GroupByFamily returns the skus matching the filters grouped by family
+// name, in canonical order within each family.
+func (c *Cache) GroupByFamily(ctx context.Context, filters ...FilterFn) map[string][]SKU {
+	return GroupByFamily(c.List(ctx, filters...))
+}
+
+// Len returns the number of skus in the cache.
+func (c *Cache) Len(ctx context.Context) int {
+	return len(c.skus(ctx))
+}
+
+// At returns the sku at index i of the cache, without copying the rest
+// of the data. Indexes are stable until the cache refreshes, so callers
+// iterating concurrently with refreshes, including those of WithTTL,
+// should use List instead. It panics if i is out of range, like a slice
+// index.
+func (c *Cache) At(ctx context.Context, i int) SKU {
+	return c.skus(ctx)[i]
+}
+
+// Locations returns the locations the cache was populated with, by
+// WithLocation or WithLocations, or nil for all locations.
+func (c *Cache) Locations() []string {
+	if c.config.location != "" {
+		return []string{c.config.location}
+	}
+	return append([]string(nil), c.config.locations...)
 }
 
 // GetVirtualMachines returns the list of all virtual machines *SKUs in a given azure location.
 func (c *Cache) GetVirtualMachines(ctx context.Context) []SKU {
-	return Filter(c.data, ResourceTypeFilter(VirtualMachines))
+	return c.ofType(ctx, VirtualMachines)
+}
+
+// GetAvailableVirtualMachines returns the virtual machine skus which
+// are available in the cache location: the location is unrestricted
+// and, for zonal skus, at least one zone is unrestricted. With
+// WithIncludeRestricted, all virtual machine skus offered in the
+// location are returned.
+func (c *Cache) GetAvailableVirtualMachines(ctx context.Context) []SKU {
+	return c.ofType(ctx, VirtualMachines, c.availabilityFilter(c.config.location))
+}
+
+// GetVirtualMachinesInLocation returns the virtual machine skus offered
+// in the location, e.g. one of a cache created WithLocations.
+func (c *Cache) GetVirtualMachinesInLocation(ctx context.Context, location string) []SKU {
+	return c.ListInLocation(ctx, location, ResourceTypeFilter(VirtualMachines))
+}
+
+// GetAvailableVirtualMachinesInLocation returns the virtual machine skus
+// available in the location, like GetAvailableVirtualMachines does for
+// the cache location.
+func (c *Cache) GetAvailableVirtualMachinesInLocation(ctx context.Context, location string) []SKU {
+	location = normalizeLocation(location)
+	return c.ListInLocation(ctx, location, ResourceTypeFilter(VirtualMachines), c.availabilityFilter(location))
 }
 
 // GetVirtualMachineAvailabilityZones returns all virtual machine zones available in a given location.
@@ -229,24 +537,78 @@ func (c *Cache) GetVirtualMachineAvailabilityZonesForSize(ctx context.Context, s
 	return c.GetAvailabilityZones(ctx, ResourceTypeFilter(VirtualMachines), NameFilter(size))
 }
 
-// GetAvailabilityZones returns the list of all availability zones in a given azure location.
+// GetAvailabilityZones returns the list of all availability zones in a
+// given azure location. Restricted zones are excluded unless the cache
+// was created WithIncludeRestricted.
 func (c *Cache) GetAvailabilityZones(ctx context.Context, filters ...FilterFn) []string {
+	return c.availabilityZones(ctx, c.config.location, filters)
+}
+
+// GetAvailabilityZonesInLocation returns the list of all availability
+// zones in the location, like GetAvailabilityZones does for the cache
+// location.
+func (c *Cache) GetAvailabilityZonesInLocation(ctx context.Context, location string, filters ...FilterFn) []string {
+	return c.availabilityZones(ctx, normalizeLocation(location), filters)
+}
+
+func (c *Cache) availabilityZones(ctx context.Context, location string, filters []FilterFn) []string {
 	allZones := make(map[string]bool)
 
-	Map(c.data, func(s *SKU) SKU {
+	data, index := c.view(ctx)
+	if location != "" {
+		data = index.byLocation(data, location)
+	}
+	Map(data, func(s *SKU) SKU {
 		if All(s, filters) {
-			for zone := range s.AvailabilityZones(c.config.location) {
+			for _, zone := range c.zones(s, location) {
 				allZones[zone] = true
 			}
 		}
 		return SKU{}
 	})
 
-	result := make([]string, 0, len(allZones))
-	for zone := range allZones {
-		result = append(result, zone)
+	return sortedZones(allZones)
+}
+
+// skuLocation returns the cache location, or the single location of the
+// sku when the cache has none.
+func (c *Cache) skuLocation(s *SKU) string {
+	return locationOf(s, c.config.location)
+}
+
+// locationOf returns the location, or the single location of the sku
+// when it is empty.
+func locationOf(s *SKU, location string) string {
+	if location != "" {
+		return location
+	}
+	location, _ = s.GetLocation()
+	return location
+}
+
+// availabilityFilter matches skus available in the location, honoring
+// WithIncludeRestricted.
+func (c *Cache) availabilityFilter(location string) FilterFn {
+	return func(s *SKU) bool {
+		skuLocation := locationOf(s, location)
+		if c.config.includeRestricted {
+			return s.HasLocation(skuLocation)
+		}
+		return s.IsAvailableWithZones(skuLocation)
 	}
+}
 
+// zones returns the zones of the sku in the location, honoring
+// WithIncludeRestricted.
+func (c *Cache) zones(s *SKU, location string) []string {
+	if c.config.includeRestricted {
+		return s.locationZones(location)
+	}
+	available := s.AvailabilityZones(location)
+	result := make([]string, 0, len(available))
+	for zone := range available {
+		result = append(result, zone)
+	}
 	return result
 }
 
@@ -262,7 +624,8 @@ func (c *Config) Equal(other *Config) bool {
 		return false
 	}
 	return c.location == other.location &&
-		c.filter == other.filter
+		c.filter == other.filter &&
+		stringSlicesEqual(c.locations, other.locations)
 }
 
 // Equal compares two caches.
@@ -279,11 +642,12 @@ func (c *Cache) Equal(other *Cache) bool {
 	if c != nil && other != nil {
 		return c.config.Equal(other.config)
 	}
-	if len(c.data) != len(other.data) {
+	data, otherData := c.current(), other.current()
+	if len(data) != len(otherData) {
 		return false
 	}
-	for i := range c.data {
-		if c.data[i] != other.data[i] {
+	for i := range data {
+		if data[i] != otherData[i] {
 			return false
 		}
 	}
@@ -349,6 +713,19 @@ func NameFilter(name string) func(*SKU) bool {
 	}
 }
 
+// FamilyFilter produces a filter function matching skus belonging to
+// any of the provided families, e.g. "standardDSv3Family".
+func FamilyFilter(families ...string) func(*SKU) bool {
+	return func(s *SKU) bool {
+		for _, family := range families {
+			if strings.EqualFold(s.GetFamilyName(), family) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
 // LocationFilter matches against a SKU listing the given location
 func LocationFilter(location string) func(*SKU) bool {
 	return func(s *SKU) bool {
@@ -356,6 +733,23 @@ func LocationFilter(location string) func(*SKU) bool {
 	}
 }
 
+// HyperVGenerationFilter produces a filter function matching skus which
+// support the provided hyper-v generation.
+func HyperVGenerationFilter(generation HyperVGeneration) func(*SKU) bool {
+	return func(s *SKU) bool {
+		return s.IsHyperVGenerationSupported(generation)
+	}
+}
+
+// ArchitectureFilter produces a filter function matching skus with the
+// provided cpu architecture.
+func ArchitectureFilter(architecture Architecture) func(*SKU) bool {
+	return func(s *SKU) bool {
+		got, err := s.CPUArchitecture()
+		return err == nil && got == architecture
+	}
+}
+
 // UnsafeLocationFilter produces a filter function for the location of a
 // resource sku.
 // This function dangerously ignores all SKUS without a properly
@@ -2,8 +2,11 @@ package skewer
 
 import (
 	"context"
+	"fmt"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-03-01/compute" //nolint:staticcheck
 	"github.com/Azure/go-autorest/autorest/to"
@@ -35,6 +38,40 @@ func Test_WithLocation(t *testing.T) {
 				},
 			},
 		},
+		"should normalize location display name": {
+			options: []Option{WithLocation("East US 2")},
+			expect: &Cache{
+				config: &Config{
+					filter:   "location eq 'eastus2'",
+					location: "eastus2",
+				},
+			},
+		},
+		"should normalize and dedupe locations": {
+			options: []Option{WithLocations("East US", "westus2", "eastus")},
+			expect: &Cache{
+				config: &Config{
+					locations: []string{"eastus", "westus2"},
+				},
+			},
+		},
+		"should treat a single location like WithLocation": {
+			options: []Option{WithLocation("foo"), WithLocations("westus2")},
+			expect: &Cache{
+				config: &Config{
+					filter:   "location eq 'westus2'",
+					location: "westus2",
+				},
+			},
+		},
+		"should replace location": {
+			options: []Option{WithLocation("foo"), WithLocations("eastus", "westus2")},
+			expect: &Cache{
+				config: &Config{
+					locations: []string{"eastus", "westus2"},
+				},
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -573,3 +610,317 @@ func Test_Cache_GetVirtualMachineAvailabilityZonesForSize(t *testing.T) { //noli
 		})
 	}
 }
+
+func Test_Cache_GetAvailableVirtualMachines(t *testing.T) {
+	newSKU := func(name string, restrictions ...compute.ResourceSkuRestrictions) compute.ResourceSku {
+		return compute.ResourceSku{
+			Name:         to.StringPtr(name),
+			ResourceType: to.StringPtr(VirtualMachines),
+			Locations:    &[]string{"baz"},
+			LocationInfo: &[]compute.ResourceSkuLocationInfo{
+				{
+					Location: to.StringPtr("baz"),
+					Zones:    &[]string{"1", "2"},
+				},
+			},
+			Restrictions: &restrictions,
+		}
+	}
+	have := Wrap([]compute.ResourceSku{
+		newSKU("unrestricted"),
+		newSKU("restricted elsewhere", compute.ResourceSkuRestrictions{
+			Type:   compute.Location,
+			Values: &[]string{"other"},
+		}),
+		newSKU("location restricted", compute.ResourceSkuRestrictions{
+			Type:   compute.Location,
+			Values: &[]string{"baz"},
+		}),
+		newSKU("partially zone restricted", compute.ResourceSkuRestrictions{
+			Type:            compute.Zone,
+			Values:          &[]string{"baz"},
+			RestrictionInfo: &compute.ResourceSkuRestrictionInfo{Zones: &[]string{"1"}},
+		}),
+		newSKU("fully zone restricted", compute.ResourceSkuRestrictions{
+			Type:            compute.Zone,
+			Values:          &[]string{"baz"},
+			RestrictionInfo: &compute.ResourceSkuRestrictionInfo{Zones: &[]string{"1", "2"}},
+		}),
+	})
+
+	cases := map[string]struct {
+		options     []Option
+		expect      []string
+		expectZones []string
+	}{
+		"should exclude location and fully zone restricted skus": {
+			options: []Option{WithLocation("baz")},
+			expect:  []string{"partially zone restricted", "restricted elsewhere", "unrestricted"},
+		},
+		"should include restricted skus and zones when requested": {
+			options: []Option{WithLocation("baz"), WithIncludeRestricted()},
+			expect: []string{
+				"fully zone restricted",
+				"location restricted",
+				"partially zone restricted",
+				"restricted elsewhere",
+				"unrestricted",
+			},
+			expectZones: []string{"1", "2"},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			cache, err := NewStaticCache(have, tc.options...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.expect, skuNames(cache.GetAvailableVirtualMachines(context.Background()))); diff != "" {
+				t.Error(diff)
+			}
+			zones := cache.GetVirtualMachineAvailabilityZonesForSize(context.Background(), "fully zone restricted")
+			if diff := cmp.Diff(tc.expectZones, zones, cmpopts.EquateEmpty(), cmpopts.SortSlices(func(a, b string) bool {
+				return a < b
+			})); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func Test_WithoutPromo(t *testing.T) {
+	data := Wrap([]compute.ResourceSku{
+		{Name: to.StringPtr("Standard_D13_v2_Promo")},
+		{Name: to.StringPtr("Standard_D13_v2")},
+	})
+	if !data[0].IsPromo() || data[1].IsPromo() {
+		t.Errorf("expected only %s to be promo", data[0].GetName())
+	}
+
+	cache, err := NewStaticCache(data, WithoutPromo())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]SKU{data[1]}, cache.List(context.Background())); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func Test_Cache_At(t *testing.T) {
+	ctx := context.Background()
+	cache, err := NewStaticCache(Wrap([]compute.ResourceSku{
+		{Name: to.StringPtr("foo")},
+		{Name: to.StringPtr("bar")},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(2, cache.Len(ctx)); diff != "" {
+		t.Error(diff)
+	}
+	for i, expect := range []string{"bar", "foo"} {
+		sku := cache.At(ctx, i)
+		if diff := cmp.Diff(expect, sku.GetName()); diff != "" {
+			t.Error(diff)
+		}
+	}
+}
+
+func Test_Cache_Concurrent(t *testing.T) {
+	dataWrapper, err := newDataWrapper("./testdata/eastus.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	cache, err := NewCache(ctx, WithClient(&fakeClient{skus: dataWrapper.Value}), WithLocation("eastus"), WithQueryCache(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				if err := cache.Refresh(ctx); err != nil {
+					errs <- err
+					return
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				if got := len(cache.List(ctx)); got != expectedVirtualMachinesCount {
+					errs <- fmt.Errorf("expected %d skus, got %d", expectedVirtualMachinesCount, got)
+					return
+				}
+				if _, err := cache.Get(ctx, "Standard_D4s_v3", VirtualMachines, "eastus"); err != nil {
+					errs <- err
+					return
+				}
+				cache.ListSupporting(ctx, []string{CapabilityPremiumIO})
+				cache.Query(ctx, "vms", ResourceTypeFilter(VirtualMachines))
+				cache.GetAvailabilityZones(ctx)
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+func Test_Cache_WithTTL(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)
+	client := &fakeClient{skus: []compute.ResourceSku{{Name: to.StringPtr("a"), Locations: &[]string{"eastus"}}}}
+	clock := func(c *Config) (*Config, error) {
+		c.clock = func() time.Time { return now }
+		return c, nil
+	}
+
+	cache, err := NewCache(ctx, WithClient(client), WithTTL(time.Hour), clock)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client.skus = append(client.skus, compute.ResourceSku{Name: to.StringPtr("b"), Locations: &[]string{"eastus"}})
+	now = now.Add(59 * time.Minute)
+	if got := len(cache.List(ctx)); got != 1 {
+		t.Errorf("expected fresh data to be served without refreshing, got %d skus", got)
+	}
+
+	now = now.Add(time.Minute)
+	if got := len(cache.List(ctx)); got != 2 {
+		t.Errorf("expected expired data to be refreshed, got %d skus", got)
+	}
+
+	client.err = fmt.Errorf("unavailable")
+	now = now.Add(time.Hour)
+	if got := len(cache.List(ctx)); got != 2 {
+		t.Errorf("expected failed refresh to keep previous data, got %d skus", got)
+	}
+
+	client.err = nil
+	client.skus = client.skus[:1]
+	if got := len(cache.List(ctx)); got != 1 {
+		t.Errorf("expected failed refresh to be retried, got %d skus", got)
+	}
+
+	static, err := NewStaticCache(Wrap(client.skus), WithTTL(time.Nanosecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := len(static.List(ctx)); got != 1 {
+		t.Errorf("expected static cache to keep its data, got %d skus", got)
+	}
+}
+
+// locationClient lists the skus of the location of a filter, like the
+// resource sku API does.
+type locationClient struct {
+	mu    sync.Mutex
+	skus  map[string][]compute.ResourceSku
+	calls int
+	err   error
+}
+
+func (l *locationClient) List(ctx context.Context, filter, includeExtendedLocations string) ([]compute.ResourceSku, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.calls++
+	if l.err != nil {
+		return nil, l.err
+	}
+	return l.skus[strings.TrimSuffix(strings.TrimPrefix(filter, "location eq '"), "'")], nil
+}
+
+func Test_Cache_WithLocations(t *testing.T) {
+	ctx := context.Background()
+	zone := func(name, location string, zones ...string) compute.ResourceSku {
+		return compute.ResourceSku{
+			Name:         to.StringPtr(name),
+			ResourceType: to.StringPtr(VirtualMachines),
+			Locations:    &[]string{location},
+			LocationInfo: &[]compute.ResourceSkuLocationInfo{{Location: to.StringPtr(location), Zones: &zones}},
+		}
+	}
+	restricted := zone("Standard_D4s_v3", "westus2", "1")
+	restricted.Restrictions = &[]compute.ResourceSkuRestrictions{{
+		Type:   compute.Location,
+		Values: &[]string{"westus2"},
+	}}
+	client := &locationClient{skus: map[string][]compute.ResourceSku{
+		"eastus":  {zone("Standard_D2s_v3", "eastus", "1", "2"), zone("Standard_D4s_v3", "eastus", "3")},
+		"westus2": {zone("Standard_D2s_v3", "westus2", "2"), restricted},
+	}}
+
+	cache, err := NewCache(ctx, WithClient(client), WithLocations("eastus", "West US 2"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if client.calls != 2 {
+		t.Errorf("expected one list per location, got %d", client.calls)
+	}
+	if diff := cmp.Diff([]string{"eastus", "westus2"}, cache.Locations()); diff != "" {
+		t.Errorf("expected and actual locations mismatch: %s", diff)
+	}
+	if cache.Len(ctx) != 4 {
+		t.Errorf("expected 4 skus, got %d", cache.Len(ctx))
+	}
+
+	if diff := cmp.Diff([]string{"Standard_D2s_v3", "Standard_D4s_v3"}, skuNames(cache.GetVirtualMachinesInLocation(ctx, "westus2"))); diff != "" {
+		t.Errorf("expected and actual virtual machines mismatch: %s", diff)
+	}
+	if diff := cmp.Diff([]string{"Standard_D2s_v3"}, skuNames(cache.GetAvailableVirtualMachinesInLocation(ctx, "West US 2"))); diff != "" {
+		t.Errorf("expected and actual available virtual machines mismatch: %s", diff)
+	}
+	if diff := cmp.Diff([]string{"1", "2", "3"}, cache.GetAvailabilityZonesInLocation(ctx, "eastus")); diff != "" {
+		t.Errorf("expected and actual eastus zones mismatch: %s", diff)
+	}
+	if diff := cmp.Diff([]string{"2"}, cache.GetAvailabilityZonesInLocation(ctx, "westus2")); diff != "" {
+		t.Errorf("expected and actual westus2 zones mismatch: %s", diff)
+	}
+
+	client.err = fmt.Errorf("list failed")
+	if _, err := NewCache(ctx, WithClient(client), WithLocations("eastus", "westus2")); err == nil {
+		t.Errorf("expected error when a location fails to list")
+	}
+}
+
+func Test_Cache_LenRefreshesExpiredData(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)
+	clock := func(c *Config) (*Config, error) {
+		c.clock = func() time.Time { return now }
+		return c, nil
+	}
+	client := &fakeClient{skus: []compute.ResourceSku{{Name: to.StringPtr("a"), Locations: &[]string{"eastus"}}}}
+	cache, err := NewCache(ctx, WithClient(client), WithTTL(time.Hour), clock)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client.skus = append(client.skus, compute.ResourceSku{
+		Name:         to.StringPtr("b"),
+		Locations:    &[]string{"eastus"},
+		Restrictions: &[]compute.ResourceSkuRestrictions{{Type: compute.Location, Values: &[]string{"eastus"}, ReasonCode: compute.QuotaID}},
+	})
+	now = now.Add(time.Hour)
+	if got := cache.Len(ctx); got != 2 {
+		t.Errorf("expected Len to refresh expired data, got %d skus", got)
+	}
+	if sku := cache.At(ctx, 1); sku.GetName() != "b" {
+		t.Errorf("expected At to read refreshed data, got %s", sku.GetName())
+	}
+	if got := len(cache.RestrictionReport(ctx, "eastus")[RestrictionReasonQuotaID]); got != 1 {
+		t.Errorf("expected RestrictionReport to read refreshed data, got %d families", got)
+	}
+}
@@ -0,0 +1,64 @@
+package skewer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-03-01/compute" //nolint:staticcheck
+)
+
+// maxCachedDiskSizeGB is the size from which Azure does not support
+// host caching for a disk, 4 TiB.
+const maxCachedDiskSizeGB = 4 * gibibytesPerTiB
+
+// ErrHostCachingUnsupported will be returned when a host caching mode
+// can not be used for a disk on a VM size.
+type ErrHostCachingUnsupported struct {
+	Size   string
+	Mode   compute.CachingTypes
+	Reason string
+}
+
+func (e *ErrHostCachingUnsupported) Error() string {
+	return fmt.Sprintf("host caching mode %s is not supported on sku %s: %s", e.Mode, e.Size, e.Reason)
+}
+
+// HostCachingModes returns the host caching modes supported for disks
+// attached to this VM size. The API does not publish them: sizes
+// without a cache, reporting no CachedDiskBytes, only support
+// compute.CachingTypesNone.
+func (s *SKU) HostCachingModes() []compute.CachingTypes {
+	if bytes, err := s.MaxCachedDiskBytes(); err != nil || bytes <= 0 {
+		return []compute.CachingTypes{compute.CachingTypesNone}
+	}
+	return []compute.CachingTypes{compute.CachingTypesNone, compute.CachingTypesReadOnly, compute.CachingTypesReadWrite}
+}
+
+// ValidateDataDiskCaching returns an error when the host caching mode
+// can not be used for a data disk of the storage account type and size
+// on this VM size. Empty disk types and zero sizes are not checked.
+// Ultra and Premium SSD v2 disks, and disks of 4 TiB or more, do not
+// support host caching.
+func (s *SKU) ValidateDataDiskCaching(mode compute.CachingTypes, diskType compute.StorageAccountTypes, diskSizeGB int32) error {
+	if strings.EqualFold(string(mode), string(compute.CachingTypesNone)) {
+		return nil
+	}
+
+	unsupported := func(reason string) error {
+		return &ErrHostCachingUnsupported{Size: s.GetName(), Mode: mode, Reason: reason}
+	}
+
+	if strings.EqualFold(string(diskType), string(compute.StorageAccountTypesUltraSSDLRS)) ||
+		strings.EqualFold(string(diskType), string(compute.StorageAccountTypesPremiumV2LRS)) {
+		return unsupported(fmt.Sprintf("disk type %s does not support host caching", diskType))
+	}
+	if diskSizeGB >= maxCachedDiskSizeGB {
+		return unsupported(fmt.Sprintf("disks of %d GB do not support host caching", diskSizeGB))
+	}
+	for _, supported := range s.HostCachingModes() {
+		if strings.EqualFold(string(mode), string(supported)) {
+			return nil
+		}
+	}
+	return unsupported("the vm size has no host cache")
+}
@@ -0,0 +1,73 @@
+package skewer
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-03-01/compute" //nolint:staticcheck
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_SKU_ValidateDataDiskCaching(t *testing.T) {
+	cached := SKU{
+		Name: to.StringPtr("Standard_D4s_v3"),
+		Capabilities: &[]compute.ResourceSkuCapabilities{
+			{Name: to.StringPtr(CachedDiskBytes), Value: to.StringPtr("107374182400")},
+		},
+	}
+	uncached := SKU{Name: to.StringPtr("Standard_L8s_v3")}
+
+	cases := map[string]struct {
+		sku        SKU
+		mode       compute.CachingTypes
+		diskType   compute.StorageAccountTypes
+		diskSizeGB int32
+		expectErr  bool
+	}{
+		"none should always be supported": {
+			sku:      uncached,
+			mode:     compute.CachingTypesNone,
+			diskType: compute.StorageAccountTypesUltraSSDLRS,
+		},
+		"read only should be supported with a cache": {
+			sku:        cached,
+			mode:       compute.CachingTypesReadOnly,
+			diskType:   compute.StorageAccountTypesPremiumLRS,
+			diskSizeGB: 1024,
+		},
+		"read write should not be supported without a cache": {
+			sku:       uncached,
+			mode:      compute.CachingTypesReadWrite,
+			expectErr: true,
+		},
+		"read only should not be supported for ultra disks": {
+			sku:       cached,
+			mode:      compute.CachingTypesReadOnly,
+			diskType:  compute.StorageAccountTypesUltraSSDLRS,
+			expectErr: true,
+		},
+		"read only should not be supported for large disks": {
+			sku:        cached,
+			mode:       compute.CachingTypesReadOnly,
+			diskSizeGB: 4096,
+			expectErr:  true,
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			err := tc.sku.ValidateDataDiskCaching(tc.mode, tc.diskType, tc.diskSizeGB)
+			if diff := cmp.Diff(tc.expectErr, err != nil); diff != "" {
+				t.Errorf("unexpected error '%v': %s", err, diff)
+			}
+		})
+	}
+}
+
+func Test_SKU_HostCachingModes(t *testing.T) {
+	sku := SKU{}
+	if diff := cmp.Diff([]compute.CachingTypes{compute.CachingTypesNone}, sku.HostCachingModes()); diff != "" {
+		t.Error(diff)
+	}
+}
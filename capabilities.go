@@ -0,0 +1,299 @@
+package skewer
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CapabilityType models the type of value a capability holds.
+type CapabilityType string
+
+const (
+	// CapabilityTypeUnknown is used for capabilities skewer does not know.
+	CapabilityTypeUnknown CapabilityType = "unknown"
+	// CapabilityTypeBool is used for "True"/"False" capabilities.
+	CapabilityTypeBool CapabilityType = "bool"
+	// CapabilityTypeInteger is used for integer quantities.
+	CapabilityTypeInteger CapabilityType = "integer"
+	// CapabilityTypeFloat is used for floating point quantities.
+	CapabilityTypeFloat CapabilityType = "float"
+	// CapabilityTypeString is used for free form string values.
+	CapabilityTypeString CapabilityType = "string"
+	// CapabilityTypeList is used for comma-separated lists of values.
+	CapabilityTypeList CapabilityType = "list"
+)
+
+// CapabilityInfo documents a capability: the type of its value, the
+// unit of quantities, and a short description.
+type CapabilityInfo struct {
+	Name        string
+	Type        CapabilityType
+	Unit        string
+	Description string
+}
+
+// capabilityInfos documents the capabilities known to skewer.
+var capabilityInfos = []CapabilityInfo{
+	{VCPUs, CapabilityTypeInteger, "count", "number of vCPUs"},
+	{CapabilityVCPUsAvailable, CapabilityTypeInteger, "count", "number of vCPUs available to the guest"},
+	{CapabilityVCPUsPerCore, CapabilityTypeInteger, "count", "number of vCPUs per physical core"},
+	{GPUs, CapabilityTypeInteger, "count", "number of GPUs"},
+	{MemoryGB, CapabilityTypeFloat, "GB", "memory capacity"},
+	{CapabilityACUs, CapabilityTypeInteger, "ACU", "azure compute units per vCPU"},
+	{MaxResourceVolumeMB, CapabilityTypeInteger, "MB", "size of the temporary disk"},
+	{CachedDiskBytes, CapabilityTypeInteger, "bytes", "size of the cache disk"},
+	{CapabilityOSVhdSizeMB, CapabilityTypeInteger, "MB", "maximum size of the os disk"},
+	{CapabilityMaxDataDiskCount, CapabilityTypeInteger, "count", "maximum number of data disks"},
+	{CapabilityMaxNetworkInterfaces, CapabilityTypeInteger, "count", "maximum number of network interfaces"},
+	{CapabilityCombinedTempDiskAndCachedIOPS, CapabilityTypeInteger, "IOPS", "combined temp disk and cache IOPS"},
+	{CapabilityCombinedTempDiskAndCachedReadBytesPerSecond, CapabilityTypeInteger, "bytes/s", "combined temp disk and cache read throughput"},   //nolint:lll
+	{CapabilityCombinedTempDiskAndCachedWriteBytesPerSecond, CapabilityTypeInteger, "bytes/s", "combined temp disk and cache write throughput"}, //nolint:lll
+	{CapabilityUncachedDiskIOPS, CapabilityTypeInteger, "IOPS", "uncached disk IOPS"},
+	{CapabilityUncachedDiskBytesPerSecond, CapabilityTypeInteger, "bytes/s", "uncached disk throughput"},
+	{CapabilityNvmeDiskSizeInMiB, CapabilityTypeInteger, "MiB", "total size of the local nvme disks"},
+	{CapabilityMaxWriteAcceleratorDisksAllowed, CapabilityTypeInteger, "count", "maximum number of write accelerator disks"},
+	{CapabilityMaximumPlatformFaultDomainCount, CapabilityTypeInteger, "count", "maximum number of availability set fault domains"},
+	{CapabilityMaxSizeGiB, CapabilityTypeInteger, "GiB", "maximum size of a disk"},
+	{CapabilityMinSizeGiB, CapabilityTypeInteger, "GiB", "minimum size of a disk"},
+	{CapabilityMaxIOps, CapabilityTypeInteger, "IOPS", "maximum IOPS of a disk"},
+	{CapabilityMinIOps, CapabilityTypeInteger, "IOPS", "minimum IOPS of a disk"},
+	{CapabilityMaxBandwidthMBps, CapabilityTypeInteger, "MB/s", "maximum throughput of a disk"},
+	{CapabilityMinBandwidthMBps, CapabilityTypeInteger, "MB/s", "minimum throughput of a disk"},
+	{EphemeralOSDisk, CapabilityTypeBool, "", "ephemeral os disk support"},
+	{EncryptionAtHost, CapabilityTypeBool, "", "encryption at host support"},
+	{AcceleratedNetworking, CapabilityTypeBool, "", "accelerated networking support"},
+	{UltraSSDAvailable, CapabilityTypeBool, "", "ultra ssd support"},
+	{CapabilityPremiumIO, CapabilityTypeBool, "", "premium storage support"},
+	{CapabilityLowPriorityCapable, CapabilityTypeBool, "", "spot and low priority support"},
+	{CapabilityRdmaEnabled, CapabilityTypeBool, "", "RDMA support"},
+	{CapabilityCapacityReservationSupported, CapabilityTypeBool, "", "capacity reservation support"},
+	{CapabilityMemoryPreservingMaintenanceSupported, CapabilityTypeBool, "", "memory preserving maintenance support"},
+	{CapabilityTrustedLaunchDisabled, CapabilityTypeBool, "", "whether trusted launch is disabled"},
+	{HyperVGenerations, CapabilityTypeList, "", "supported hyper-v generations"},
+	{CapabilityVMDeploymentTypes, CapabilityTypeList, "", "supported deployment types"},
+	{CapabilityDiskControllerTypes, CapabilityTypeList, "", "supported disk controllers"},
+	{CapabilitySupportedEphemeralOSDiskPlacements, CapabilityTypeList, "", "supported ephemeral os disk placements"},
+	{CapabilityCPUArchitectureType, CapabilityTypeString, "", "cpu architecture"},
+	{CapabilityConfidentialComputingType, CapabilityTypeString, "", "confidential computing technology"},
+	{CapabilityRetirementDateUtc, CapabilityTypeString, "", "announced retirement date"},
+}
+
+// capabilityInfoIndex indexes capabilityInfos by lower case name.
+var capabilityInfoIndex = func() map[string]CapabilityInfo {
+	index := make(map[string]CapabilityInfo, len(capabilityInfos))
+	for _, info := range capabilityInfos {
+		index[strings.ToLower(info.Name)] = info
+	}
+	return index
+}()
+
+// DescribeCapability returns the documentation for the named
+// capability, matched case-insensitively. Unknown capabilities are
+// returned with CapabilityTypeUnknown and no description.
+func DescribeCapability(name string) CapabilityInfo {
+	if info, ok := capabilityInfoIndex[strings.ToLower(name)]; ok {
+		return info
+	}
+	return CapabilityInfo{
+		Name: name,
+		Type: CapabilityTypeUnknown,
+	}
+}
+
+// KnownCapabilities returns the documentation of every capability known
+// to skewer, sorted by name, e.g. to validate or pretty-print
+// capabilities without hard-coding their names.
+func KnownCapabilities() []CapabilityInfo {
+	result := make([]CapabilityInfo, len(capabilityInfos))
+	copy(result, capabilityInfos)
+	sort.Slice(result, func(i, j int) bool {
+		return strings.ToLower(result[i].Name) < strings.ToLower(result[j].Name)
+	})
+	return result
+}
+
+// Get retrieves the capability from the sku and parses it as its
+// documented type. Names match exactly, as for GetCapabilityString.
+func (i *CapabilityInfo) Get(s *SKU) (Quantity, error) {
+	value, err := s.GetCapabilityString(i.Name)
+	if err != nil {
+		return Quantity{}, err
+	}
+	return parseQuantity(i.Type, i.Name, value)
+}
+
+// Quantity is a capability value parsed according to its documented
+// CapabilityType. Only the field matching Type is populated; Raw always
+// holds the unparsed value.
+type Quantity struct {
+	Raw   string
+	Type  CapabilityType
+	Int   int64
+	Float float64
+	Bool  bool
+	List  []string
+}
+
+// Evaluate retrieves and parses many capabilities in a single pass. It
+// returns the parsed quantities by requested name along with one error
+// per capability which could not be found, was nil, or failed to parse.
+func (s *SKU) Evaluate(names ...string) (map[string]Quantity, []error) {
+	values := make(map[string]*string)
+	if s.Capabilities != nil {
+		for _, capability := range *s.Capabilities {
+			if capability.Name == nil {
+				continue
+			}
+			if _, ok := values[strings.ToLower(*capability.Name)]; !ok {
+				values[strings.ToLower(*capability.Name)] = capability.Value
+			}
+		}
+	}
+
+	result := make(map[string]Quantity, len(names))
+	var errs []error
+	for _, name := range names {
+		value, ok := values[strings.ToLower(name)]
+		if !ok {
+			errs = append(errs, &ErrCapabilityNotFound{name})
+			continue
+		}
+		if value == nil {
+			errs = append(errs, &ErrCapabilityValueNil{name})
+			continue
+		}
+		quantity, err := parseQuantity(DescribeCapability(name).Type, name, *value)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		result[name] = quantity
+	}
+
+	return result, errs
+}
+
+// parseQuantity parses the raw value of the named capability as the
+// provided type.
+func parseQuantity(capabilityType CapabilityType, name, value string) (Quantity, error) {
+	quantity := Quantity{Raw: value, Type: capabilityType}
+	switch capabilityType {
+	case CapabilityTypeInteger:
+		intVal, err := strconv.ParseInt(value, ten, sixtyFour)
+		if err != nil {
+			return Quantity{}, &ErrCapabilityValueParse{name, value, err}
+		}
+		quantity.Int = intVal
+	case CapabilityTypeFloat:
+		floatVal, err := strconv.ParseFloat(value, sixtyFour)
+		if err != nil {
+			return Quantity{}, &ErrCapabilityValueParse{name, value, err}
+		}
+		quantity.Float = floatVal
+	case CapabilityTypeBool:
+		switch {
+		case strings.EqualFold(value, string(CapabilitySupported)):
+			quantity.Bool = true
+		case strings.EqualFold(value, string(CapabilityUnsupported)):
+			quantity.Bool = false
+		default:
+			return Quantity{}, &ErrCapabilityValueParse{name, value, fmt.Errorf("not a boolean value")}
+		}
+	case CapabilityTypeList:
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				quantity.List = append(quantity.List, item)
+			}
+		}
+	}
+	return quantity, nil
+}
+
+// CapabilityValue constrains the types GetCapability parses values as.
+type CapabilityValue interface {
+	int64 | float64 | bool | string
+}
+
+// GetCapability retrieves the named capability and parses its value as
+// T, where booleans are parsed from "True" and "False". Names match
+// exactly, as for GetCapabilityIntegerQuantity. It errors with the zero
+// value of T if the capability is not found, the value was nil, or the
+// value could not be parsed as T.
+func GetCapability[T CapabilityValue](s *SKU, name string) (T, error) {
+	var result T
+	value, err := s.GetCapabilityString(name)
+	if err != nil {
+		return result, err
+	}
+
+	var capabilityType CapabilityType
+	switch any(result).(type) {
+	case int64:
+		capabilityType = CapabilityTypeInteger
+	case float64:
+		capabilityType = CapabilityTypeFloat
+	case bool:
+		capabilityType = CapabilityTypeBool
+	default:
+		capabilityType = CapabilityTypeString
+	}
+	quantity, err := parseQuantity(capabilityType, name, value)
+	if err != nil {
+		return result, err
+	}
+
+	var parsed interface{}
+	switch capabilityType { //nolint:exhaustive
+	case CapabilityTypeInteger:
+		parsed = quantity.Int
+	case CapabilityTypeFloat:
+		parsed = quantity.Float
+	case CapabilityTypeBool:
+		parsed = quantity.Bool
+	default:
+		parsed = quantity.Raw
+	}
+	return parsed.(T), nil
+}
+
+// Capability is a capability value parsed on a best-effort basis: each
+// of Int, Float and Bool is set when Raw parses as that type.
+type Capability struct {
+	Name  string   `json:"name"`
+	Raw   string   `json:"raw"`
+	Int   *int64   `json:"int,omitempty"`
+	Float *float64 `json:"float,omitempty"`
+	Bool  *bool    `json:"bool,omitempty"`
+}
+
+// ParsedCapabilities returns every capability of the sku in API order,
+// with values parsed as every type they are valid for, e.g. to dump all
+// a sku supports in one pass. Capabilities without a name are skipped.
+func (s *SKU) ParsedCapabilities() []Capability {
+	if s.Capabilities == nil {
+		return nil
+	}
+	result := make([]Capability, 0, len(*s.Capabilities))
+	for _, capability := range *s.Capabilities {
+		if capability.Name == nil {
+			continue
+		}
+		parsed := Capability{Name: *capability.Name}
+		if capability.Value != nil {
+			parsed.Raw = *capability.Value
+		}
+		if intVal, err := strconv.ParseInt(parsed.Raw, ten, sixtyFour); err == nil {
+			parsed.Int = &intVal
+		}
+		if floatVal, err := strconv.ParseFloat(parsed.Raw, sixtyFour); err == nil {
+			parsed.Float = &floatVal
+		}
+		if quantity, err := parseQuantity(CapabilityTypeBool, parsed.Name, parsed.Raw); err == nil {
+			parsed.Bool = &quantity.Bool
+		}
+		result = append(result, parsed)
+	}
+	return result
+}
@@ -0,0 +1,193 @@
+package skewer
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-03-01/compute" //nolint:staticcheck
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_DescribeCapability(t *testing.T) {
+	cases := map[string]struct {
+		name   string
+		expect CapabilityInfo
+	}{
+		"should describe unknown capability": {
+			name:   "foo",
+			expect: CapabilityInfo{Name: "foo", Type: CapabilityTypeUnknown},
+		},
+		"should describe known capability regardless of case": {
+			name:   "memorygb",
+			expect: CapabilityInfo{Name: MemoryGB, Type: CapabilityTypeFloat, Unit: "GB", Description: "memory capacity"},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.expect, DescribeCapability(tc.name)); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func Test_KnownCapabilities(t *testing.T) {
+	known := KnownCapabilities()
+	if len(known) != len(capabilityInfos) {
+		t.Fatalf("expected %d known capabilities, got %d", len(capabilityInfos), len(known))
+	}
+	seen := map[string]bool{}
+	for i, info := range known {
+		if i > 0 && strings.ToLower(known[i-1].Name) >= strings.ToLower(info.Name) {
+			t.Errorf("expected capabilities sorted by name, got %q before %q", known[i-1].Name, info.Name)
+		}
+		if info.Type == CapabilityTypeUnknown || info.Description == "" {
+			t.Errorf("expected %q to be documented, got %+v", info.Name, info)
+		}
+		seen[info.Name] = true
+	}
+	if !seen[MemoryGB] || !seen[CapabilityMaxWriteAcceleratorDisksAllowed] {
+		t.Errorf("expected %q and %q to be known", MemoryGB, CapabilityMaxWriteAcceleratorDisksAllowed)
+	}
+
+	known[0].Name = "mutated"
+	if KnownCapabilities()[0].Name == "mutated" {
+		t.Error("expected KnownCapabilities to return a copy")
+	}
+}
+
+func Test_CapabilityInfo_Get(t *testing.T) {
+	sku := &SKU{
+		Capabilities: &[]compute.ResourceSkuCapabilities{
+			{Name: to.StringPtr(MemoryGB), Value: to.StringPtr("3.5")},
+			{Name: to.StringPtr(CapabilityPremiumIO), Value: to.StringPtr("maybe")},
+		},
+	}
+
+	memory := DescribeCapability(MemoryGB)
+	got, err := memory.Get(sku)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(Quantity{Raw: "3.5", Type: CapabilityTypeFloat, Float: 3.5}, got); diff != "" {
+		t.Error(diff)
+	}
+
+	premium := DescribeCapability(CapabilityPremiumIO)
+	var parseErr *ErrCapabilityValueParse
+	if _, err := premium.Get(sku); !errors.As(err, &parseErr) {
+		t.Errorf("expected parse error, got %v", err)
+	}
+
+	vcpus := DescribeCapability(VCPUs)
+	var notFound *ErrCapabilityNotFound
+	if _, err := vcpus.Get(sku); !errors.As(err, &notFound) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
+
+func Test_SKU_Evaluate(t *testing.T) {
+	sku := SKU{
+		Capabilities: &[]compute.ResourceSkuCapabilities{
+			{Name: to.StringPtr(VCPUs), Value: to.StringPtr("4")},
+			{Name: to.StringPtr(MemoryGB), Value: to.StringPtr("3.5")},
+			{Name: to.StringPtr(CapabilityPremiumIO), Value: to.StringPtr("True")},
+			{Name: to.StringPtr(HyperVGenerations), Value: to.StringPtr("V1,V2")},
+			{Name: to.StringPtr(GPUs), Value: to.StringPtr("many")},
+			{Name: to.StringPtr("foo"), Value: nil},
+			{Name: to.StringPtr("bar"), Value: to.StringPtr("baz")},
+		},
+	}
+
+	got, errs := sku.Evaluate(VCPUs, "memorygb", CapabilityPremiumIO, HyperVGenerations, GPUs, "foo", "bar", "missing")
+
+	expect := map[string]Quantity{
+		VCPUs:               {Raw: "4", Type: CapabilityTypeInteger, Int: 4},
+		"memorygb":          {Raw: "3.5", Type: CapabilityTypeFloat, Float: 3.5},
+		CapabilityPremiumIO: {Raw: "True", Type: CapabilityTypeBool, Bool: true},
+		HyperVGenerations:   {Raw: "V1,V2", Type: CapabilityTypeList, List: []string{"V1", "V2"}},
+		"bar":               {Raw: "baz", Type: CapabilityTypeUnknown},
+	}
+	if diff := cmp.Diff(expect, got); diff != "" {
+		t.Error(diff)
+	}
+
+	var errParse *ErrCapabilityValueParse
+	var errNil *ErrCapabilityValueNil
+	var errNotFound *ErrCapabilityNotFound
+	if len(errs) != 3 || !errors.As(errs[0], &errParse) || !errors.As(errs[1], &errNil) || !errors.As(errs[2], &errNotFound) {
+		t.Errorf("expected parse, nil and not found errors, got %v", errs)
+	}
+}
+
+func Test_GetCapability(t *testing.T) {
+	sku := &SKU{
+		Capabilities: &[]compute.ResourceSkuCapabilities{
+			{Name: to.StringPtr(VCPUs), Value: to.StringPtr("4")},
+			{Name: to.StringPtr(MemoryGB), Value: to.StringPtr("3.5")},
+			{Name: to.StringPtr(CapabilityPremiumIO), Value: to.StringPtr("True")},
+			{Name: to.StringPtr(CapabilityCPUArchitectureType), Value: to.StringPtr("x64")},
+			{Name: to.StringPtr("foo"), Value: nil},
+		},
+	}
+
+	if value, err := GetCapability[int64](sku, VCPUs); value != 4 || err != nil {
+		t.Errorf("expected 4 vCPUs, got value '%d' and error '%v'", value, err)
+	}
+	if value, err := GetCapability[float64](sku, MemoryGB); value != 3.5 || err != nil {
+		t.Errorf("expected 3.5GB of memory, got value '%f' and error '%v'", value, err)
+	}
+	if value, err := GetCapability[bool](sku, CapabilityPremiumIO); !value || err != nil {
+		t.Errorf("expected premium io, got value '%t' and error '%v'", value, err)
+	}
+	if value, err := GetCapability[string](sku, CapabilityCPUArchitectureType); value != "x64" || err != nil {
+		t.Errorf("expected x64, got value '%s' and error '%v'", value, err)
+	}
+
+	var errParse *ErrCapabilityValueParse
+	if value, err := GetCapability[int64](sku, MemoryGB); value != 0 || !errors.As(err, &errParse) {
+		t.Errorf("expected parse error, got value '%d' and error '%v'", value, err)
+	}
+	if value, err := GetCapability[bool](sku, VCPUs); value || !errors.As(err, &errParse) {
+		t.Errorf("expected parse error, got value '%t' and error '%v'", value, err)
+	}
+	var errNil *ErrCapabilityValueNil
+	if _, err := GetCapability[string](sku, "foo"); !errors.As(err, &errNil) {
+		t.Errorf("expected nil value error, got '%v'", err)
+	}
+	var errNotFound *ErrCapabilityNotFound
+	if _, err := GetCapability[float64](sku, "missing"); !errors.As(err, &errNotFound) {
+		t.Errorf("expected not found error, got '%v'", err)
+	}
+}
+
+func Test_SKU_ParsedCapabilities(t *testing.T) {
+	sku := SKU{
+		Capabilities: &[]compute.ResourceSkuCapabilities{
+			{Name: to.StringPtr(VCPUs), Value: to.StringPtr("4")},
+			{Name: to.StringPtr(MemoryGB), Value: to.StringPtr("3.5")},
+			{Name: to.StringPtr(CapabilityPremiumIO), Value: to.StringPtr("False")},
+			{Name: to.StringPtr(HyperVGenerations), Value: to.StringPtr("V1,V2")},
+			{Name: to.StringPtr("foo")},
+			{Value: to.StringPtr("nameless")},
+		},
+	}
+
+	expect := []Capability{
+		{Name: VCPUs, Raw: "4", Int: to.Int64Ptr(4), Float: to.Float64Ptr(4)},
+		{Name: MemoryGB, Raw: "3.5", Float: to.Float64Ptr(3.5)},
+		{Name: CapabilityPremiumIO, Raw: "False", Bool: to.BoolPtr(false)},
+		{Name: HyperVGenerations, Raw: "V1,V2"},
+		{Name: "foo"},
+	}
+	if diff := cmp.Diff(expect, sku.ParsedCapabilities()); diff != "" {
+		t.Error(diff)
+	}
+	if (&SKU{}).ParsedCapabilities() != nil {
+		t.Error("expected nil capabilities to parse to nil")
+	}
+}
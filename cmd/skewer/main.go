@@ -0,0 +1,67 @@
+// Command skewer inspects the resource sku catalog of a subscription.
+//
+// Usage:
+//
+//	skewer audit -subscription <id> [-location <location>]
+//
+// The audit subcommand prints an integrity report of the catalog as
+// JSON. It authenticates with the Azure CLI.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-03-01/compute" //nolint:staticcheck
+	"github.com/Azure/go-autorest/autorest/azure/auth"
+	"github.com/Azure/skewer"
+)
+
+func main() {
+	if len(os.Args) < 2 || os.Args[1] != "audit" {
+		fmt.Fprintln(os.Stderr, "usage: skewer audit -subscription <id> [-location <location>]")
+		os.Exit(2)
+	}
+	if err := audit(os.Args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "audit failed: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func audit(args []string) error {
+	flags := flag.NewFlagSet("audit", flag.ExitOnError)
+	subscriptionID := flags.String("subscription", "", "subscription id to audit")
+	location := flags.String("location", "", "optional location to restrict the audit to")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *subscriptionID == "" {
+		return fmt.Errorf("-subscription is required")
+	}
+
+	authorizer, err := auth.NewAuthorizerFromCLI()
+	if err != nil {
+		return err
+	}
+
+	client := compute.NewResourceSkusClient(*subscriptionID)
+	client.Authorizer = authorizer
+
+	opts := []skewer.Option{skewer.WithResourceClient(client)}
+	if *location != "" {
+		opts = append(opts, skewer.WithLocation(*location))
+	}
+
+	ctx := context.Background()
+	cache, err := skewer.NewCache(ctx, opts...)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(skewer.Audit(cache.List(ctx)))
+}
@@ -0,0 +1,152 @@
+package skewer
+
+import (
+	"bytes"
+	"compress/flate"
+	"container/list"
+	"context"
+	"encoding/gob"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// CompactCache stores skus compressed in memory, keeping only an index
+// by resource type, name and location uncompressed. Skus are decoded on
+// demand and the most recently used are kept hydrated, which suits
+// memory constrained processes with occasional full capability queries.
+// It is safe for concurrent use.
+type CompactCache struct {
+	mu       sync.Mutex
+	blobs    [][]byte
+	index    map[string][]int
+	capacity int
+	lru      *list.List
+	hydrated map[int]*list.Element
+}
+
+// hydratedSKU is an entry of the least recently used list.
+type hydratedSKU struct {
+	i   int
+	sku SKU
+}
+
+// NewCompactCache compresses data into a compact cache keeping at most
+// capacity skus hydrated. A capacity below one hydrates a single sku.
+func NewCompactCache(data []SKU, capacity int) (*CompactCache, error) {
+	if capacity < 1 {
+		capacity = 1
+	}
+	c := &CompactCache{
+		blobs:    make([][]byte, len(data)),
+		index:    make(map[string][]int),
+		capacity: capacity,
+		lru:      list.New(),
+		hydrated: make(map[int]*list.Element),
+	}
+	for i := range data {
+		blob, err := compressSKU(&data[i])
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to compress sku %s", data[i].GetName())
+		}
+		c.blobs[i] = blob
+		if data[i].Locations == nil {
+			continue
+		}
+		for _, location := range *data[i].Locations {
+			key := compactKey(data[i].GetName(), data[i].GetResourceType(), location)
+			c.index[key] = append(c.index[key], i)
+		}
+	}
+	return c, nil
+}
+
+// Compact returns a compact copy of the data of the cache, keeping at
+// most capacity skus hydrated.
+func (c *Cache) Compact(capacity int) (*CompactCache, error) {
+	return NewCompactCache(c.current(), capacity)
+}
+
+// Len returns the number of skus in the cache.
+func (c *CompactCache) Len() int {
+	return len(c.blobs)
+}
+
+// At returns the sku at index i of the cache, decoding it if it is not
+// hydrated. It panics if i is out of range, like a slice index.
+func (c *CompactCache) At(i int) (SKU, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.hydrate(i)
+}
+
+// Get returns the matching resource of a given name and type in a
+// location, with the same errors as Cache.Get.
+func (c *CompactCache) Get(ctx context.Context, name, resourceType, location string) (SKU, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	matches := c.index[compactKey(name, resourceType, location)]
+	if len(matches) > 1 {
+		return SKU{}, &ErrMultipleSKUsMatch{Name: name, Location: location, Type: resourceType}
+	}
+	if len(matches) < 1 {
+		return SKU{}, &ErrSKUNotFound{Name: name, Location: location, Type: resourceType}
+	}
+	return c.hydrate(matches[0])
+}
+
+// hydrate returns the sku at index i and marks it most recently used,
+// evicting the least recently used sku beyond capacity. The caller
+// must hold the lock.
+func (c *CompactCache) hydrate(i int) (SKU, error) {
+	if element, ok := c.hydrated[i]; ok {
+		c.lru.MoveToFront(element)
+		return element.Value.(*hydratedSKU).sku, nil
+	}
+
+	sku, err := decompressSKU(c.blobs[i])
+	if err != nil {
+		return SKU{}, err
+	}
+	c.hydrated[i] = c.lru.PushFront(&hydratedSKU{i: i, sku: sku})
+	if c.lru.Len() > c.capacity {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.hydrated, oldest.Value.(*hydratedSKU).i)
+	}
+	return sku, nil
+}
+
+func compactKey(name, resourceType, location string) string {
+	return strings.ToLower(resourceType) + "/" + strings.ToLower(name) + "/" + normalizeLocation(location)
+}
+
+// compressSKU encodes a sku with gob, which unlike the sdk JSON
+// marshalers keeps read-only fields, and compresses it. Gob does not
+// encode empty lists, which decode as nil: accessors treat both alike.
+func compressSKU(sku *SKU) ([]byte, error) {
+	var buf bytes.Buffer
+	writer, err := flate.NewWriter(&buf, flate.BestCompression)
+	if err != nil {
+		return nil, err
+	}
+	if err := gob.NewEncoder(writer).Encode(sku); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func decompressSKU(blob []byte) (SKU, error) {
+	var sku SKU
+	reader := flate.NewReader(bytes.NewReader(blob))
+	defer reader.Close()
+	if err := gob.NewDecoder(reader).Decode(&sku); err != nil {
+		return SKU{}, errors.Wrap(err, "failed to decompress sku")
+	}
+	return sku, nil
+}
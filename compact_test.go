@@ -0,0 +1,83 @@
+package skewer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-03-01/compute" //nolint:staticcheck
+	"github.com/google/go-cmp/cmp"
+)
+
+// equateEmptyLists treats empty lists like nil ones, since neither gob
+// nor the flattened json schema encode them.
+var equateEmptyLists = cmp.Options{
+	cmp.Transformer("emptyZoneDetails", func(in *[]compute.ResourceSkuZoneDetails) *[]compute.ResourceSkuZoneDetails {
+		if in != nil && len(*in) == 0 {
+			return nil
+		}
+		return in
+	}),
+	cmp.Transformer("emptyRestrictions", func(in *[]compute.ResourceSkuRestrictions) *[]compute.ResourceSkuRestrictions {
+		if in != nil && len(*in) == 0 {
+			return nil
+		}
+		return in
+	}),
+}
+
+func Test_CompactCache(t *testing.T) {
+	dataWrapper, err := newDataWrapper("./testdata/eastus.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache, err := NewStaticCache(Wrap(dataWrapper.Value))
+	if err != nil {
+		t.Fatal(err)
+	}
+	compact, err := cache.Compact(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	if diff := cmp.Diff(cache.Len(ctx), compact.Len()); diff != "" {
+		t.Error(diff)
+	}
+
+	t.Run("should round trip every sku", func(t *testing.T) {
+		for i := 0; i < compact.Len(); i++ {
+			sku, err := compact.At(i)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(cache.At(ctx, i), sku, equateEmptyLists); diff != "" {
+				t.Error(diff)
+			}
+		}
+		if diff := cmp.Diff(2, compact.lru.Len()); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("should get by name, type and location", func(t *testing.T) {
+		expect, err := cache.Get(ctx, "standard_d4s_v3", VirtualMachines, "eastus")
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := compact.Get(ctx, "standard_d4s_v3", VirtualMachines, "EastUS")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(expect, got, equateEmptyLists); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("should not find missing sku", func(t *testing.T) {
+		var errSKUNotFound *ErrSKUNotFound
+		if _, err := compact.Get(ctx, "standard_foo", VirtualMachines, "eastus"); !errors.As(err, &errSKUNotFound) {
+			t.Errorf("expected sku not found, got '%v'", err)
+		}
+	})
+}
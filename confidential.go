@@ -0,0 +1,76 @@
+package skewer
+
+import "strings"
+
+// epcMemoryMiB holds the enclave page cache memory of SGX capable VM
+// sizes, keyed by lower case size.
+// See https://learn.microsoft.com/en-us/azure/virtual-machines/dcv2-series
+// and https://learn.microsoft.com/en-us/azure/virtual-machines/dcv3-series
+var epcMemoryMiB = map[string]int64{
+	"dc1s_v2":   28,
+	"dc2s_v2":   56,
+	"dc4s_v2":   112,
+	"dc8_v2":    168,
+	"dc1s_v3":   4 * mebibytesPerGiB,
+	"dc2s_v3":   8 * mebibytesPerGiB,
+	"dc4s_v3":   16 * mebibytesPerGiB,
+	"dc8s_v3":   32 * mebibytesPerGiB,
+	"dc16s_v3":  64 * mebibytesPerGiB,
+	"dc24s_v3":  128 * mebibytesPerGiB,
+	"dc32s_v3":  192 * mebibytesPerGiB,
+	"dc48s_v3":  256 * mebibytesPerGiB,
+	"dc1ds_v3":  4 * mebibytesPerGiB,
+	"dc2ds_v3":  8 * mebibytesPerGiB,
+	"dc4ds_v3":  16 * mebibytesPerGiB,
+	"dc8ds_v3":  32 * mebibytesPerGiB,
+	"dc16ds_v3": 64 * mebibytesPerGiB,
+	"dc24ds_v3": 128 * mebibytesPerGiB,
+	"dc32ds_v3": 192 * mebibytesPerGiB,
+	"dc48ds_v3": 256 * mebibytesPerGiB,
+}
+
+// EPCMemoryMiB returns the enclave page cache memory available to SGX
+// enclaves on this VM size. It errors when the size is not a known SGX
+// capable size.
+func (s *SKU) EPCMemoryMiB() (int64, error) {
+	if value, ok := epcMemoryMiB[strings.ToLower(s.GetSize())]; ok {
+		return value, nil
+	}
+	return -1, &ErrCapabilityNotFound{CapabilityEPCMemoryMiB}
+}
+
+// ConfidentialComputingType returns the confidential computing type of
+// this sku, such as ConfidentialComputingTypeSNP or
+// ConfidentialComputingTypeTDX. SGX capable sizes do not report the
+// capability, so known SGX sizes return ConfidentialComputingTypeSGX.
+func (s *SKU) ConfidentialComputingType() (string, error) {
+	value, err := s.GetCapabilityString(CapabilityConfidentialComputingType)
+	if err != nil {
+		if _, ok := epcMemoryMiB[strings.ToLower(s.GetSize())]; ok {
+			return ConfidentialComputingTypeSGX, nil
+		}
+		return "", err
+	}
+	return value, nil
+}
+
+// IsConfidentialComputingSupported returns true when the sku supports
+// any type of confidential computing.
+func (s *SKU) IsConfidentialComputingSupported() bool {
+	value, err := s.ConfidentialComputingType()
+	return err == nil && value != ""
+}
+
+// IsSGXSupported returns true when the sku supports intel SGX enclaves.
+func (s *SKU) IsSGXSupported() bool {
+	value, err := s.ConfidentialComputingType()
+	return err == nil && strings.EqualFold(value, ConfidentialComputingTypeSGX)
+}
+
+// HasEPCMemoryMiB returns true when the VM size supports SGX with at
+// least the requested enclave page cache memory, e.g. to check enclave
+// capacity before scheduling confidential containers.
+func (s *SKU) HasEPCMemoryMiB(mib int64) bool {
+	value, err := s.EPCMemoryMiB()
+	return err == nil && value >= mib
+}
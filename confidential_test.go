@@ -0,0 +1,157 @@
+package skewer
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-03-01/compute" //nolint:staticcheck
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_SKU_EPCMemoryMiB(t *testing.T) {
+	cases := map[string]struct {
+		sku    compute.ResourceSku
+		expect int64
+		err    string
+	}{
+		"should error for unknown size": {
+			sku: compute.ResourceSku{Size: to.StringPtr("D4s_v3")},
+			err: (&ErrCapabilityNotFound{CapabilityEPCMemoryMiB}).Error(),
+		},
+		"should return epc memory for dcsv2": {
+			sku:    compute.ResourceSku{Size: to.StringPtr("DC4s_v2")},
+			expect: 112,
+		},
+		"should return epc memory for dcsv3": {
+			sku:    compute.ResourceSku{Size: to.StringPtr("DC8s_v3")},
+			expect: 32 * 1024,
+		},
+		"should return epc memory for smallest dcdsv3": {
+			sku:    compute.ResourceSku{Size: to.StringPtr("DC1ds_v3")},
+			expect: 4 * 1024,
+		},
+		"should return epc memory for largest dcdsv3": {
+			sku:    compute.ResourceSku{Size: to.StringPtr("DC48ds_v3")},
+			expect: 256 * 1024,
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			sku := SKU(tc.sku)
+			got, err := sku.EPCMemoryMiB()
+			if tc.err != "" {
+				if err == nil || err.Error() != tc.err {
+					t.Errorf("expected error '%s', got '%v'", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.expect, got); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func Test_SKU_ConfidentialComputingType(t *testing.T) {
+	cases := map[string]struct {
+		sku             compute.ResourceSku
+		expect          string
+		expectSupported bool
+		err             string
+	}{
+		"should error without capability": {
+			sku: compute.ResourceSku{Size: to.StringPtr("D4s_v3")},
+			err: (&ErrCapabilityNotFound{CapabilityConfidentialComputingType}).Error(),
+		},
+		"should return reported type": {
+			sku: compute.ResourceSku{
+				Size: to.StringPtr("DC4as_v5"),
+				Capabilities: &[]compute.ResourceSkuCapabilities{
+					{Name: to.StringPtr(CapabilityConfidentialComputingType), Value: to.StringPtr(ConfidentialComputingTypeSNP)},
+				},
+			},
+			expect:          ConfidentialComputingTypeSNP,
+			expectSupported: true,
+		},
+		"should return sgx for known sgx size": {
+			sku:             compute.ResourceSku{Size: to.StringPtr("DC4s_v3")},
+			expect:          ConfidentialComputingTypeSGX,
+			expectSupported: true,
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			sku := SKU(tc.sku)
+			if diff := cmp.Diff(tc.expectSupported, sku.IsConfidentialComputingSupported()); diff != "" {
+				t.Error(diff)
+			}
+			got, err := sku.ConfidentialComputingType()
+			if tc.err != "" {
+				if err == nil || err.Error() != tc.err {
+					t.Errorf("expected error '%s', got '%v'", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.expect, got); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func Test_SKU_SGX(t *testing.T) {
+	cases := map[string]struct {
+		sku           compute.ResourceSku
+		mib           int64
+		expectSGX     bool
+		expectEnclave bool
+	}{
+		"should not support sgx on general purpose size": {
+			sku: compute.ResourceSku{Size: to.StringPtr("D4s_v3")},
+			mib: 1,
+		},
+		"should not support sgx on snp size": {
+			sku: compute.ResourceSku{
+				Size: to.StringPtr("DC4as_v5"),
+				Capabilities: &[]compute.ResourceSkuCapabilities{
+					{Name: to.StringPtr(CapabilityConfidentialComputingType), Value: to.StringPtr(ConfidentialComputingTypeSNP)},
+				},
+			},
+			mib: 1,
+		},
+		"should fit enclave within epc memory": {
+			sku:           compute.ResourceSku{Size: to.StringPtr("DC4s_v2")},
+			mib:           112,
+			expectSGX:     true,
+			expectEnclave: true,
+		},
+		"should not fit enclave larger than epc memory": {
+			sku:       compute.ResourceSku{Size: to.StringPtr("DC4s_v2")},
+			mib:       113,
+			expectSGX: true,
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			sku := SKU(tc.sku)
+			if got := sku.IsSGXSupported(); got != tc.expectSGX {
+				t.Errorf("expected sgx support %t, got %t", tc.expectSGX, got)
+			}
+			if got := sku.HasEPCMemoryMiB(tc.mib); got != tc.expectEnclave {
+				t.Errorf("expected epc capacity for %d MiB %t, got %t", tc.mib, tc.expectEnclave, got)
+			}
+		})
+	}
+}
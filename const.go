@@ -5,6 +5,12 @@ const (
 	VirtualMachines = "virtualMachines"
 	// Disks is a convenience constant to filter resource SKUs to only include disks.
 	Disks = "disks"
+	// AvailabilitySets is a convenience constant to filter resource SKUs to only include availability sets.
+	AvailabilitySets = "availabilitySets"
+	// DedicatedHosts is a convenience constant to filter resource SKUs to only include dedicated hosts.
+	DedicatedHosts = "hostGroups/hosts"
+	// Snapshots is a convenience constant to filter resource SKUs to only include snapshots.
+	Snapshots = "snapshots"
 )
 
 // Supported models an enum of possible boolean values for resource support in the Azure API.
@@ -15,6 +21,9 @@ const (
 	CapabilitySupported Supported = "True"
 	// CapabilityUnsupported is an enum value for the string "False" returned when a SKU does not support a binary capability.
 	CapabilityUnsupported Supported = "False"
+	// CapabilityUnknown is an enum value returned when a SKU does not
+	// report a binary capability, or reports an unrecognized value.
+	CapabilityUnknown Supported = ""
 )
 
 const (
@@ -51,18 +60,128 @@ const (
 	CapabilityConfidentialComputingType = "ConfidentialComputingType"
 	// ConfidentialComputingTypeSNP denoted the "SNP" ConfidentialComputing.
 	ConfidentialComputingTypeSNP = "SNP"
+	// ConfidentialComputingTypeTDX denoted the "TDX" ConfidentialComputing.
+	ConfidentialComputingTypeTDX = "TDX"
+	// ConfidentialComputingTypeSGX denoted the "SGX" ConfidentialComputing.
+	ConfidentialComputingTypeSGX = "SGX"
+	// CapabilityOSVhdSizeMB identifies the maximum size of the os disk.
+	CapabilityOSVhdSizeMB = "OSVhdSizeMB"
+	// CapabilityMaxDataDiskCount identifies the maximum number of data disks.
+	CapabilityMaxDataDiskCount = "MaxDataDiskCount"
+	// CapabilityMaxNetworkInterfaces identifies the maximum number of network interfaces.
+	CapabilityMaxNetworkInterfaces = "MaxNetworkInterfaces"
+	// CapabilityLowPriorityCapable identifies whether spot and low priority deployments are supported.
+	CapabilityLowPriorityCapable = "LowPriorityCapable"
+	// CapabilityVCPUsAvailable identifies the number of vCPUs available to the guest.
+	CapabilityVCPUsAvailable = "vCPUsAvailable"
+	// CapabilityVCPUsPerCore identifies the number of vCPUs per physical core.
+	CapabilityVCPUsPerCore = "vCPUsPerCore"
+	// CapabilityACUs identifies the azure compute units of a vm.
+	CapabilityACUs = "ACUs"
+	// CapabilityRdmaEnabled identifies the capability for RDMA support.
+	CapabilityRdmaEnabled = "RdmaEnabled"
+	// CapabilityCapacityReservationSupported identifies the capability for capacity reservations.
+	CapabilityCapacityReservationSupported = "CapacityReservationSupported"
+	// CapabilityMemoryPreservingMaintenanceSupported identifies the capability for memory preserving maintenance.
+	CapabilityMemoryPreservingMaintenanceSupported = "MemoryPreservingMaintenanceSupported"
+	// CapabilityVMDeploymentTypes identifies the deployment types of a vm, e.g. "IaaS,PaaS".
+	CapabilityVMDeploymentTypes = "VMDeploymentTypes"
+	// CapabilityRetirementDateUtc identifies the announced retirement date of a vm.
+	CapabilityRetirementDateUtc = "RetirementDateUtc"
+	// CapabilityCombinedTempDiskAndCachedIOPS identifies the combined temp disk and cache IOPS.
+	CapabilityCombinedTempDiskAndCachedIOPS = "CombinedTempDiskAndCachedIOPS"
+	// CapabilityCombinedTempDiskAndCachedReadBytesPerSecond identifies the combined temp disk and cache read throughput.
+	CapabilityCombinedTempDiskAndCachedReadBytesPerSecond = "CombinedTempDiskAndCachedReadBytesPerSecond"
+	// CapabilityCombinedTempDiskAndCachedWriteBytesPerSecond identifies the combined temp disk and cache write throughput.
+	CapabilityCombinedTempDiskAndCachedWriteBytesPerSecond = "CombinedTempDiskAndCachedWriteBytesPerSecond"
+	// CapabilityUncachedDiskIOPS identifies the uncached disk IOPS.
+	CapabilityUncachedDiskIOPS = "UncachedDiskIOPS"
+	// CapabilityUncachedDiskBytesPerSecond identifies the uncached disk throughput.
+	CapabilityUncachedDiskBytesPerSecond = "UncachedDiskBytesPerSecond"
+	// CapabilityDiskControllerTypes identifies the supported disk controllers, e.g. "SCSI, NVMe".
+	CapabilityDiskControllerTypes = "DiskControllerTypes"
+	// CapabilityNvmeDiskSizeInMiB identifies the total size of the local NVMe disks.
+	CapabilityNvmeDiskSizeInMiB = "NvmeDiskSizeInMiB"
+	// DiskControllerTypeNVMe denotes the "NVMe" disk controller.
+	DiskControllerTypeNVMe = "NVMe"
+	// CapabilitySupportedEphemeralOSDiskPlacements identifies the placements of ephemeral os disks.
+	CapabilitySupportedEphemeralOSDiskPlacements = "SupportedEphemeralOSDiskPlacements"
+	// CapabilityMaxWriteAcceleratorDisksAllowed identifies the number of disks which may enable write accelerator.
+	CapabilityMaxWriteAcceleratorDisksAllowed = "MaxWriteAcceleratorDisksAllowed"
+	// CapabilityMaximumPlatformFaultDomainCount identifies the maximum number of fault domains of an availability set.
+	CapabilityMaximumPlatformFaultDomainCount = "MaximumPlatformFaultDomainCount"
+	// CapabilityMaxSizeGiB identifies the maximum size of a disk.
+	CapabilityMaxSizeGiB = "MaxSizeGiB"
+	// CapabilityMinSizeGiB identifies the minimum size of a disk.
+	CapabilityMinSizeGiB = "MinSizeGiB"
+	// CapabilityMaxIOps identifies the maximum IOPS of a disk.
+	CapabilityMaxIOps = "MaxIOps"
+	// CapabilityMinIOps identifies the minimum IOPS of a disk.
+	CapabilityMinIOps = "MinIOps"
+	// CapabilityMaxBandwidthMBps identifies the maximum throughput of a disk.
+	CapabilityMaxBandwidthMBps = "MaxBandwidthMBps"
+	// CapabilityMinBandwidthMBps identifies the minimum throughput of a disk.
+	CapabilityMinBandwidthMBps = "MinBandwidthMBps"
+	// CapabilityEPCMemoryMiB identifies the enclave page cache memory of SGX capable vms.
+	// The resource sku API does not publish it, so it is served from a maintained table.
+	CapabilityEPCMemoryMiB = "EPCMemoryMiB"
+)
+
+// Architecture models an enum of cpu architectures a vm sku may have.
+type Architecture string
+
+const (
+	// ArchitectureX64 identifies x64 cpus.
+	ArchitectureX64 Architecture = "x64"
+	// ArchitectureArm64 identifies Arm64 cpus, e.g. Ampere Altra.
+	ArchitectureArm64 Architecture = "Arm64"
+)
+
+// EphemeralOSDiskPlacement models an enum of locations an ephemeral os
+// disk may be placed on.
+type EphemeralOSDiskPlacement string
+
+const (
+	// EphemeralOSDiskPlacementCacheDisk places the os disk on the cache disk.
+	EphemeralOSDiskPlacementCacheDisk EphemeralOSDiskPlacement = "CacheDisk"
+	// EphemeralOSDiskPlacementResourceDisk places the os disk on the temporary disk.
+	EphemeralOSDiskPlacementResourceDisk EphemeralOSDiskPlacement = "ResourceDisk"
+	// EphemeralOSDiskPlacementNvmeDisk places the os disk on a local nvme disk.
+	EphemeralOSDiskPlacementNvmeDisk EphemeralOSDiskPlacement = "NvmeDisk"
+)
+
+// HyperVGeneration models an enum of hyper-v generations a vm sku may support.
+type HyperVGeneration string
+
+const (
+	// HyperVGenerationV1 identifies hyper-v generation 1.
+	HyperVGenerationV1 HyperVGeneration = "V1"
+	// HyperVGenerationV2 identifies hyper-v generation 2.
+	HyperVGenerationV2 HyperVGeneration = "V2"
 )
 
 const (
 	// HyperVGeneration1 identifies a sku which supports HyperV
 	// Generation 1.
-	HyperVGeneration1 = "V1"
+	//
+	// Deprecated: use HyperVGenerationV1.
+	HyperVGeneration1 = string(HyperVGenerationV1)
 	// HyperVGeneration2 identifies a sku which supports HyperV
 	// Generation 2.
-	HyperVGeneration2 = "V2"
+	//
+	// Deprecated: use HyperVGenerationV2.
+	HyperVGeneration2 = string(HyperVGenerationV2)
 )
 
 const (
 	ten       = 10
 	sixtyFour = 64
 )
+
+const (
+	millicoresPerCore = 1000
+	mebibytesPerGiB   = 1024
+	gibibytesPerTiB   = 1024
+	bytesPerMiB       = 1024 * 1024
+	bytesPerGiB       = bytesPerMiB * mebibytesPerGiB
+)
@@ -150,6 +150,12 @@ func Test_Data(t *testing.T) {
 					if !sku.IsPremiumIO() {
 						t.Errorf("expected standard_d4s_v3 to support PremiumIO")
 					}
+					if !sku.IsSpotCapable() {
+						t.Errorf("expected standard_d4s_v3 to be spot capable")
+					}
+					if sku.IsCapacityReservationSupported() {
+						t.Errorf("expected standard_d4s_v3 not to support capacity reservations")
+					}
 					if !sku.IsHyperVGen1Supported() {
 						t.Errorf("expected standard_d4s_v3 to support hyper v gen1")
 					}
@@ -174,6 +180,30 @@ func Test_Data(t *testing.T) {
 					if quantity, err := sku.MaxResourceVolumeMB(); quantity != 32768 || errors.As(err, &errCapabilityNotFound) {
 						t.Errorf("expected standard_d4s_v3 to have 32768 MB of temporary disk, got value '%d' and error '%s'", quantity, err)
 					}
+					if quantity, err := sku.OSVhdSizeMB(); quantity != 1047552 || err != nil {
+						t.Errorf("expected standard_d4s_v3 to support 1047552 MB os disks, got value '%d' and error '%s'", quantity, err)
+					}
+					if performance, err := sku.CombinedTempDiskAndCached(); err != nil || performance != (DiskPerformance{IOPS: 8000, ReadBps: 67108864, WriteBps: 67108864}) {
+						t.Errorf("expected standard_d4s_v3 to have 8000 cached IOPS and 64MiB/s cached throughput, got value '%+v' and error '%s'", performance, err)
+					}
+					if quantity, err := sku.VCPUsAvailable(); quantity != 4 || err != nil {
+						t.Errorf("expected standard_d4s_v3 to have 4 available vCPUs, got value '%d' and error '%s'", quantity, err)
+					}
+					if quantity, err := sku.VCPUsPerCore(); quantity != 2 || err != nil {
+						t.Errorf("expected standard_d4s_v3 to have 2 vCPUs per core, got value '%d' and error '%s'", quantity, err)
+					}
+					if sku.IsConstrainedCoreSKU() {
+						t.Errorf("expected standard_d4s_v3 not to be a constrained core sku")
+					}
+					if quantity, err := sku.ACUs(); quantity != 160 || err != nil {
+						t.Errorf("expected standard_d4s_v3 to have 160 ACUs, got value '%d' and error '%s'", quantity, err)
+					}
+					if quantity, err := sku.MaxDataDisks(); quantity != 8 || err != nil {
+						t.Errorf("expected standard_d4s_v3 to support 8 data disks, got value '%d' and error '%s'", quantity, err)
+					}
+					if quantity, err := sku.MaxNICs(); quantity != 2 || err != nil {
+						t.Errorf("expected standard_d4s_v3 to support 2 network interfaces, got value '%d' and error '%s'", quantity, err)
+					}
 					if isSupported, err := sku.HasCapabilityWithMinCapacity("MaxResourceVolumeMB", 32768); !isSupported || err != nil {
 						t.Errorf("expected standard_d4s_v3 to  fit 32GB temp disk, got '%t', error: %s", isSupported, err)
 					}
@@ -403,3 +433,36 @@ func Test_Data(t *testing.T) {
 		})
 	}
 }
+
+func Test_Data_LocationDisplayName(t *testing.T) {
+	dataWrapper, err := newDataWrapper("./testdata/eastus.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	cache, err := NewCache(ctx, WithClient(&fakeClient{skus: dataWrapper.Value}), WithLocation("East US"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sku, err := cache.Get(ctx, "standard_d4s_v3", VirtualMachines, " East US ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sku.IsAvailable("East US") {
+		t.Error("expected sku to be available in East US")
+	}
+	if sku.IsRestricted("East US") {
+		t.Error("expected sku not to be restricted in East US")
+	}
+	if diff := cmp.Diff(sku.AvailabilityZones("eastus"), sku.AvailabilityZones("East US")); diff != "" {
+		t.Error(diff)
+	}
+	if len(sku.AvailabilityZones("East US")) == 0 {
+		t.Error("expected zones in East US")
+	}
+	if diff := cmp.Diff(expectedAvailabilityZones, cache.GetVirtualMachineAvailabilityZones(ctx)); diff != "" {
+		t.Error(diff)
+	}
+}
@@ -0,0 +1,40 @@
+package skewer
+
+import (
+	"context"
+	"strings"
+)
+
+// DedicatedHostSeries returns the VM series a dedicated host sku runs,
+// derived from its name, e.g. "DSv3" for "DSv3-Type1". It returns the
+// empty string for skus which are not dedicated hosts.
+func (s *SKU) DedicatedHostSeries() string {
+	if !s.IsResourceType(DedicatedHosts) {
+		return ""
+	}
+	series, _, _ := strings.Cut(s.GetName(), "-")
+	return series
+}
+
+// SupportsVMSize returns true when VMs of the size, e.g.
+// "Standard_D4s_v3", can be deployed to a dedicated host sku. Hosts only
+// run the sizes of a single series, matched by family, additive
+// features and version.
+func (s *SKU) SupportsVMSize(size string) bool {
+	series := s.DedicatedHostSeries()
+	if series == "" {
+		return false
+	}
+	parsed, err := ParseName(size)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(series, parsed.Family()+parsed.Subfamily()+parsed.AdditiveFeatures()+parsed.Version())
+}
+
+// GetDedicatedHosts returns the list of all dedicated host skus in a
+// given azure location. The vCPUs of a host are reported by VCPU, and
+// zone availability by AvailabilityZones as for virtual machines.
+func (c *Cache) GetDedicatedHosts(ctx context.Context) []SKU {
+	return c.ofType(ctx, DedicatedHosts)
+}
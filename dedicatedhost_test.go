@@ -0,0 +1,71 @@
+package skewer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-03-01/compute" //nolint:staticcheck
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_DedicatedHosts(t *testing.T) {
+	host := compute.ResourceSku{
+		ResourceType: to.StringPtr(DedicatedHosts),
+		Name:         to.StringPtr("DSv3-Type1"),
+		Locations:    &[]string{"eastus"},
+		LocationInfo: &[]compute.ResourceSkuLocationInfo{
+			{Location: to.StringPtr("eastus"), Zones: &[]string{"1", "2"}},
+		},
+		Capabilities: &[]compute.ResourceSkuCapabilities{
+			{Name: to.StringPtr(VCPUs), Value: to.StringPtr("64")},
+		},
+	}
+	vm := compute.ResourceSku{
+		ResourceType: to.StringPtr(VirtualMachines),
+		Name:         to.StringPtr("Standard_D4s_v3"),
+		Locations:    &[]string{"eastus"},
+	}
+
+	cache, err := NewStaticCache(Wrap([]compute.ResourceSku{host, vm}), WithLocation("eastus"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	hosts := cache.GetDedicatedHosts(context.Background())
+	if len(hosts) != 1 {
+		t.Fatalf("expected 1 dedicated host sku, got %d", len(hosts))
+	}
+	sku := hosts[0]
+
+	if diff := cmp.Diff("DSv3", sku.DedicatedHostSeries()); diff != "" {
+		t.Error(diff)
+	}
+	vcpus, err := sku.VCPU()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(int64(64), vcpus); diff != "" {
+		t.Error(diff)
+	}
+	if diff := cmp.Diff([]string{"1", "2"}, sku.AvailabilityZoneList("eastus")); diff != "" {
+		t.Error(diff)
+	}
+
+	for size, expect := range map[string]bool{
+		"Standard_D4s_v3":  true,
+		"Standard_D64s_v3": true,
+		"Standard_D4_v3":   false,
+		"Standard_D4s_v4":  false,
+		"Standard_E4s_v3":  false,
+		"not a size":       false,
+	} {
+		if got := sku.SupportsVMSize(size); got != expect {
+			t.Errorf("expected host to support %s %t, got %t", size, expect, got)
+		}
+	}
+
+	other := SKU(vm)
+	if other.DedicatedHostSeries() != "" || other.SupportsVMSize("Standard_D4s_v3") {
+		t.Error("expected virtual machine sku not to be a dedicated host")
+	}
+}
@@ -0,0 +1,121 @@
+package skewer
+
+import (
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-03-01/compute" //nolint:staticcheck
+)
+
+// DeepCopy returns a copy of the sku sharing no pointers with it, so
+// the copy can be mutated without corrupting cached data.
+func (s *SKU) DeepCopy() SKU {
+	result := SKU{
+		ResourceType: copyString(s.ResourceType),
+		Name:         copyString(s.Name),
+		Tier:         copyString(s.Tier),
+		Size:         copyString(s.Size),
+		Family:       copyString(s.Family),
+		Kind:         copyString(s.Kind),
+		Locations:    copyStrings(s.Locations),
+		APIVersions:  copyStrings(s.APIVersions),
+		Capabilities: copyCapabilities(s.Capabilities),
+	}
+	if s.Capacity != nil {
+		result.Capacity = &compute.ResourceSkuCapacity{
+			Minimum:   copyInt64(s.Capacity.Minimum),
+			Maximum:   copyInt64(s.Capacity.Maximum),
+			Default:   copyInt64(s.Capacity.Default),
+			ScaleType: s.Capacity.ScaleType,
+		}
+	}
+	if s.Costs != nil {
+		costs := make([]compute.ResourceSkuCosts, len(*s.Costs))
+		for i, cost := range *s.Costs {
+			costs[i] = compute.ResourceSkuCosts{
+				MeterID:      copyString(cost.MeterID),
+				Quantity:     copyInt64(cost.Quantity),
+				ExtendedUnit: copyString(cost.ExtendedUnit),
+			}
+		}
+		result.Costs = &costs
+	}
+	if s.LocationInfo != nil {
+		infos := make([]compute.ResourceSkuLocationInfo, len(*s.LocationInfo))
+		for i, info := range *s.LocationInfo {
+			infos[i] = compute.ResourceSkuLocationInfo{
+				Location:          copyString(info.Location),
+				Zones:             copyStrings(info.Zones),
+				ExtendedLocations: copyStrings(info.ExtendedLocations),
+				Type:              info.Type,
+			}
+			if info.ZoneDetails != nil {
+				details := make([]compute.ResourceSkuZoneDetails, len(*info.ZoneDetails))
+				for j, detail := range *info.ZoneDetails {
+					details[j] = compute.ResourceSkuZoneDetails{
+						Name:         copyStrings(detail.Name),
+						Capabilities: copyCapabilities(detail.Capabilities),
+					}
+				}
+				infos[i].ZoneDetails = &details
+			}
+		}
+		result.LocationInfo = &infos
+	}
+	if s.Restrictions != nil {
+		restrictions := make([]compute.ResourceSkuRestrictions, len(*s.Restrictions))
+		for i, restriction := range *s.Restrictions {
+			restrictions[i] = compute.ResourceSkuRestrictions{
+				Type:       restriction.Type,
+				Values:     copyStrings(restriction.Values),
+				ReasonCode: restriction.ReasonCode,
+			}
+			if restriction.RestrictionInfo != nil {
+				restrictions[i].RestrictionInfo = &compute.ResourceSkuRestrictionInfo{
+					Locations: copyStrings(restriction.RestrictionInfo.Locations),
+					Zones:     copyStrings(restriction.RestrictionInfo.Zones),
+				}
+			}
+		}
+		result.Restrictions = &restrictions
+	}
+	return result
+}
+
+func copyString(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	result := *value
+	return &result
+}
+
+func copyInt64(value *int64) *int64 {
+	if value == nil {
+		return nil
+	}
+	result := *value
+	return &result
+}
+
+func copyStrings(values *[]string) *[]string {
+	if values == nil {
+		return nil
+	}
+	result := append([]string(nil), *values...)
+	if result == nil {
+		result = []string{}
+	}
+	return &result
+}
+
+func copyCapabilities(capabilities *[]compute.ResourceSkuCapabilities) *[]compute.ResourceSkuCapabilities {
+	if capabilities == nil {
+		return nil
+	}
+	result := make([]compute.ResourceSkuCapabilities, len(*capabilities))
+	for i, capability := range *capabilities {
+		result[i] = compute.ResourceSkuCapabilities{
+			Name:  copyString(capability.Name),
+			Value: copyString(capability.Value),
+		}
+	}
+	return &result
+}
@@ -0,0 +1,48 @@
+package skewer
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-03-01/compute" //nolint:staticcheck
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_SKU_DeepCopy(t *testing.T) {
+	dataWrapper, err := newDataWrapper("./testdata/eastus.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	skus := Wrap(dataWrapper.Value)
+	skus[0].Capacity = &compute.ResourceSkuCapacity{Minimum: to.Int64Ptr(1)}
+	skus[0].Costs = &[]compute.ResourceSkuCosts{{MeterID: to.StringPtr("meter"), Quantity: to.Int64Ptr(2)}}
+
+	for i := range skus {
+		original := &skus[i]
+		copied := original.DeepCopy()
+		if diff := cmp.Diff(*original, copied); diff != "" {
+			t.Fatal(diff)
+		}
+
+		*(*copied.Capabilities)[0].Value = "mutated"
+		(*copied.LocationInfo)[0].Location = to.StringPtr("mutated")
+		*copied.Name = "mutated"
+		if (*original.Capabilities)[0].Value != nil && *(*original.Capabilities)[0].Value == "mutated" {
+			t.Errorf("mutating a copied capability changed %s", original.GetName())
+		}
+		if *(*original.LocationInfo)[0].Location == "mutated" || original.GetName() == "mutated" {
+			t.Errorf("mutating a copy changed %s", original.GetName())
+		}
+	}
+
+	copied := skus[0].DeepCopy()
+	*copied.Capacity.Minimum = 3
+	*(*copied.Costs)[0].Quantity = 4
+	if *skus[0].Capacity.Minimum != 1 || *(*skus[0].Costs)[0].Quantity != 2 {
+		t.Error("mutating copied capacity or costs changed the original")
+	}
+
+	if diff := cmp.Diff(SKU{}, (&SKU{}).DeepCopy()); diff != "" {
+		t.Error(diff)
+	}
+}
@@ -0,0 +1,122 @@
+package skewer
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-03-01/compute" //nolint:staticcheck
+)
+
+// AnomalyKind models an enum of data issues found in sku catalogs.
+type AnomalyKind string
+
+const (
+	// AnomalyNilName is reported for skus or capabilities without a name.
+	AnomalyNilName AnomalyKind = "NilName"
+	// AnomalyUnknownRestrictionType is reported for restrictions which
+	// are neither location nor zone restrictions.
+	AnomalyUnknownRestrictionType AnomalyKind = "UnknownRestrictionType"
+	// AnomalyUnparsableValue is reported for known capabilities whose
+	// value does not parse as the documented type.
+	AnomalyUnparsableValue AnomalyKind = "UnparsableValue"
+)
+
+// Anomaly describes a single data issue found in a sku catalog.
+type Anomaly struct {
+	Kind   AnomalyKind `json:"kind"`
+	SKU    string      `json:"sku"`
+	Detail string      `json:"detail"`
+}
+
+func (a Anomaly) String() string {
+	return fmt.Sprintf("%s: sku '%s': %s", a.Kind, a.SKU, a.Detail)
+}
+
+// DiagnosticsSink receives anomalies found while loading data.
+type DiagnosticsSink func(Anomaly)
+
+// WithDiagnostics is a functional option to report catalog data
+// anomalies to sink. Anomalies still present after a refresh are not
+// reported again, so refreshes do not repeat warnings, while anomalies
+// which disappear and later recur are. The sink is called without any
+// lock held, so it may be slow or use the cache.
+func WithDiagnostics(sink DiagnosticsSink) Option {
+	return func(c *Config) (*Config, error) {
+		c.diagnostics = &warnOnce{sink: sink}
+		return c, nil
+	}
+}
+
+// warnOnce forwards each distinct anomaly of a load to its sink, unless
+// the previous load reported it. Only the anomalies of the last load
+// are kept, bounding its memory to one catalog.
+type warnOnce struct {
+	mu   sync.Mutex
+	sink DiagnosticsSink
+	seen map[Anomaly]bool
+}
+
+// diagnose reports the anomalies found in skus which the previous call
+// did not find. It is a no-op without a diagnostics sink.
+func (w *warnOnce) diagnose(skus []SKU) {
+	if w == nil {
+		return
+	}
+
+	found := make(map[Anomaly]bool)
+	var anomalies []Anomaly
+	report := func(anomaly Anomaly) {
+		if !found[anomaly] {
+			found[anomaly] = true
+			anomalies = append(anomalies, anomaly)
+		}
+	}
+	findAnomalies(skus, report)
+
+	w.mu.Lock()
+	previous := w.seen
+	w.seen = found
+	w.mu.Unlock()
+
+	for _, anomaly := range anomalies {
+		if !previous[anomaly] {
+			w.sink(anomaly)
+		}
+	}
+}
+
+// findAnomalies calls report for every anomaly found in skus, in order.
+func findAnomalies(skus []SKU, report func(Anomaly)) {
+	for i := range skus {
+		sku := &skus[i]
+		if sku.Name == nil {
+			report(Anomaly{Kind: AnomalyNilName, Detail: fmt.Sprintf("sku of type '%s' has no name", sku.GetResourceType())})
+		}
+		if sku.Restrictions != nil {
+			for _, restriction := range *sku.Restrictions {
+				if restriction.Type != compute.Location && restriction.Type != compute.Zone {
+					report(Anomaly{
+						Kind:   AnomalyUnknownRestrictionType,
+						SKU:    sku.GetName(),
+						Detail: fmt.Sprintf("restriction type '%s'", restriction.Type),
+					})
+				}
+			}
+		}
+		if sku.Capabilities != nil {
+			for _, capability := range *sku.Capabilities {
+				if capability.Name == nil {
+					report(Anomaly{Kind: AnomalyNilName, SKU: sku.GetName(), Detail: "capability has no name"})
+					continue
+				}
+				if capability.Value == nil {
+					continue
+				}
+				info := DescribeCapability(*capability.Name)
+				if _, err := parseQuantity(info.Type, *capability.Name, *capability.Value); err != nil {
+					report(Anomaly{Kind: AnomalyUnparsableValue, SKU: sku.GetName(), Detail: err.Error()})
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package skewer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-03-01/compute" //nolint:staticcheck
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_WithDiagnostics(t *testing.T) {
+	data := Wrap([]compute.ResourceSku{
+		{
+			ResourceType: to.StringPtr(VirtualMachines),
+		},
+		{
+			Name: to.StringPtr("foo"),
+			Restrictions: &[]compute.ResourceSkuRestrictions{
+				{Type: compute.ResourceSkuRestrictionsType("Other")},
+				{Type: compute.Location},
+			},
+			Capabilities: &[]compute.ResourceSkuCapabilities{
+				{Name: to.StringPtr(VCPUs), Value: to.StringPtr("four")},
+				{Name: to.StringPtr(MemoryGB), Value: to.StringPtr("16")},
+			},
+		},
+	})
+
+	var got []AnomalyKind
+	sink := func(anomaly Anomaly) {
+		got = append(got, anomaly.Kind)
+	}
+
+	cache, err := NewStaticCache(data, WithDiagnostics(sink))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Diagnosing the same data again must not repeat warnings.
+	cache.config.diagnostics.diagnose(data)
+
+	expect := []AnomalyKind{AnomalyNilName, AnomalyUnknownRestrictionType, AnomalyUnparsableValue}
+	if diff := cmp.Diff(expect, got); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func Test_WithDiagnostics_Refresh(t *testing.T) {
+	ctx := context.Background()
+	unnamed := compute.ResourceSku{ResourceType: to.StringPtr(VirtualMachines)}
+	unparsable := compute.ResourceSku{
+		Name:         to.StringPtr("foo"),
+		Capabilities: &[]compute.ResourceSkuCapabilities{{Name: to.StringPtr(VCPUs), Value: to.StringPtr("four")}},
+	}
+	client := &fakeClient{skus: []compute.ResourceSku{unnamed}}
+
+	var cache *Cache
+	var got []AnomalyKind
+	sink := func(anomaly Anomaly) {
+		// The sink may use the cache, since it is called without locks.
+		if cache != nil {
+			cache.Len(ctx)
+		}
+		got = append(got, anomaly.Kind)
+	}
+	cache, err := NewCache(ctx, WithClient(client), WithDiagnostics(sink))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	refresh := func(skus ...compute.ResourceSku) {
+		client.skus = skus
+		if err := cache.Refresh(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	refresh(unnamed, unparsable)
+	refresh(unparsable)
+	refresh(unnamed)
+
+	expect := []AnomalyKind{AnomalyNilName, AnomalyUnparsableValue, AnomalyNilName}
+	if diff := cmp.Diff(expect, got); diff != "" {
+		t.Errorf("expected only new anomalies of each refresh to be reported: %s", diff)
+	}
+	if diff := cmp.Diff(1, len(cache.config.diagnostics.seen)); diff != "" {
+		t.Errorf("expected only the anomalies of the last load to be kept: %s", diff)
+	}
+}
@@ -0,0 +1,112 @@
+package skewer
+
+import (
+	"sort"
+	"strings"
+)
+
+// ValueChange is a capability value differing between two skus.
+type ValueChange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// SKUDiff describes how one sku differs from another, e.g. a candidate
+// replacement size or the same size in another region.
+type SKUDiff struct {
+	AddedCapabilities   map[string]string      `json:"addedCapabilities,omitempty"`
+	RemovedCapabilities map[string]string      `json:"removedCapabilities,omitempty"`
+	ChangedCapabilities map[string]ValueChange `json:"changedCapabilities,omitempty"`
+	AddedZones          []string               `json:"addedZones,omitempty"`
+	RemovedZones        []string               `json:"removedZones,omitempty"`
+	AddedRestrictions   []Restriction          `json:"addedRestrictions,omitempty"`
+	RemovedRestrictions []Restriction          `json:"removedRestrictions,omitempty"`
+}
+
+// Empty returns true when the skus do not differ.
+func (d *SKUDiff) Empty() bool {
+	return len(d.AddedCapabilities) == 0 && len(d.RemovedCapabilities) == 0 && len(d.ChangedCapabilities) == 0 &&
+		len(d.AddedZones) == 0 && len(d.RemovedZones) == 0 &&
+		len(d.AddedRestrictions) == 0 && len(d.RemovedRestrictions) == 0
+}
+
+// Diff returns the capabilities, available zones and restrictions added,
+// removed or changed from a to b. Zones and restrictions of each sku are
+// those of its own location, so the same size can be compared across
+// regions; restrictions are compared by type, reason and zones.
+func Diff(a, b *SKU) SKUDiff {
+	diff := SKUDiff{}
+
+	before, after := a.CapabilityMap(), b.CapabilityMap()
+	for name, from := range before {
+		to, ok := after[name]
+		switch {
+		case !ok:
+			if diff.RemovedCapabilities == nil {
+				diff.RemovedCapabilities = map[string]string{}
+			}
+			diff.RemovedCapabilities[name] = from
+		case from != to:
+			if diff.ChangedCapabilities == nil {
+				diff.ChangedCapabilities = map[string]ValueChange{}
+			}
+			diff.ChangedCapabilities[name] = ValueChange{From: from, To: to}
+		}
+	}
+	for name, to := range after {
+		if _, ok := before[name]; !ok {
+			if diff.AddedCapabilities == nil {
+				diff.AddedCapabilities = map[string]string{}
+			}
+			diff.AddedCapabilities[name] = to
+		}
+	}
+
+	locationA, _ := a.GetLocation()
+	locationB, _ := b.GetLocation()
+	diff.AddedZones, diff.RemovedZones = diffStrings(a.AvailabilityZoneList(locationA), b.AvailabilityZoneList(locationB))
+
+	restrictionKey := func(r Restriction) string {
+		return string(r.Type) + "/" + string(r.Reason) + "/" + strings.Join(r.Zones, ",")
+	}
+	restrictionsA, restrictionsB := a.GetRestrictions(locationA), b.GetRestrictions(locationB)
+	keysA, keysB := map[string]bool{}, map[string]bool{}
+	for _, restriction := range restrictionsA {
+		keysA[restrictionKey(restriction)] = true
+	}
+	for _, restriction := range restrictionsB {
+		keysB[restrictionKey(restriction)] = true
+		if !keysA[restrictionKey(restriction)] {
+			diff.AddedRestrictions = append(diff.AddedRestrictions, restriction)
+		}
+	}
+	for _, restriction := range restrictionsA {
+		if !keysB[restrictionKey(restriction)] {
+			diff.RemovedRestrictions = append(diff.RemovedRestrictions, restriction)
+		}
+	}
+
+	return diff
+}
+
+// diffStrings returns the sorted values only in b, then only in a.
+func diffStrings(a, b []string) (added, removed []string) {
+	inA, inB := map[string]bool{}, map[string]bool{}
+	for _, value := range a {
+		inA[value] = true
+	}
+	for _, value := range b {
+		inB[value] = true
+		if !inA[value] {
+			added = append(added, value)
+		}
+	}
+	for _, value := range a {
+		if !inB[value] {
+			removed = append(removed, value)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	return added, removed
+}
@@ -0,0 +1,68 @@
+package skewer
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-03-01/compute" //nolint:staticcheck
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_Diff(t *testing.T) {
+	a := &SKU{
+		Locations: &[]string{"eastus"},
+		LocationInfo: &[]compute.ResourceSkuLocationInfo{
+			{Location: to.StringPtr("eastus"), Zones: &[]string{"1", "2"}},
+		},
+		Capabilities: &[]compute.ResourceSkuCapabilities{
+			{Name: to.StringPtr(VCPUs), Value: to.StringPtr("4")},
+			{Name: to.StringPtr(MemoryGB), Value: to.StringPtr("16")},
+			{Name: to.StringPtr(CapabilityPremiumIO), Value: to.StringPtr("True")},
+		},
+		Restrictions: &[]compute.ResourceSkuRestrictions{
+			{
+				Type:            compute.Zone,
+				Values:          &[]string{"eastus"},
+				RestrictionInfo: &compute.ResourceSkuRestrictionInfo{Zones: &[]string{"3"}},
+				ReasonCode:      compute.NotAvailableForSubscription,
+			},
+		},
+	}
+	b := &SKU{
+		Locations: &[]string{"westus"},
+		LocationInfo: &[]compute.ResourceSkuLocationInfo{
+			{Location: to.StringPtr("westus"), Zones: &[]string{"2", "3"}},
+		},
+		Capabilities: &[]compute.ResourceSkuCapabilities{
+			{Name: to.StringPtr(VCPUs), Value: to.StringPtr("4")},
+			{Name: to.StringPtr(MemoryGB), Value: to.StringPtr("32")},
+			{Name: to.StringPtr(AcceleratedNetworking), Value: to.StringPtr("True")},
+		},
+		Restrictions: &[]compute.ResourceSkuRestrictions{
+			{Type: compute.Location, Values: &[]string{"westus"}, ReasonCode: compute.QuotaID},
+		},
+	}
+
+	expect := SKUDiff{
+		AddedCapabilities:   map[string]string{AcceleratedNetworking: "True"},
+		RemovedCapabilities: map[string]string{CapabilityPremiumIO: "True"},
+		ChangedCapabilities: map[string]ValueChange{MemoryGB: {From: "16", To: "32"}},
+		RemovedZones:        []string{"1", "2"},
+		AddedRestrictions:   []Restriction{{Type: compute.Location, Reason: RestrictionReasonQuotaID, Location: "westus"}},
+		RemovedRestrictions: []Restriction{
+			{Type: compute.Zone, Reason: RestrictionReasonNotAvailableForSubscription, Location: "eastus", Zones: []string{"3"}},
+		},
+	}
+	diff := Diff(a, b)
+	if d := cmp.Diff(expect, diff); d != "" {
+		t.Error(d)
+	}
+	if diff.Empty() {
+		t.Error("expected differing skus not to have an empty diff")
+	}
+
+	same := Diff(a, a)
+	if !same.Empty() {
+		t.Errorf("expected identical skus to have an empty diff, got %+v", same)
+	}
+}
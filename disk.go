@@ -0,0 +1,44 @@
+package skewer
+
+import "context"
+
+// MaxSizeGiB returns the maximum size of a disk sku, e.g. 1024 for a
+// P30 Premium_LRS disk.
+func (s *SKU) MaxSizeGiB() (int64, error) {
+	return s.GetCapabilityIntegerQuantity(CapabilityMaxSizeGiB)
+}
+
+// MinSizeGiB returns the minimum size of a disk sku, e.g. 513 for a P30
+// Premium_LRS disk.
+func (s *SKU) MinSizeGiB() (int64, error) {
+	return s.GetCapabilityIntegerQuantity(CapabilityMinSizeGiB)
+}
+
+// MaxIOPS returns the maximum IOPS of a disk sku.
+func (s *SKU) MaxIOPS() (int64, error) {
+	return s.GetCapabilityIntegerQuantity(CapabilityMaxIOps)
+}
+
+// MaxBandwidthMBps returns the maximum throughput of a disk sku in MB/s.
+func (s *SKU) MaxBandwidthMBps() (int64, error) {
+	return s.GetCapabilityIntegerQuantity(CapabilityMaxBandwidthMBps)
+}
+
+// FitsDiskSizeGiB returns true when a disk of the size, in GiB, falls
+// within the size range of the disk sku, e.g. to pick the performance
+// tier of a disk.
+func (s *SKU) FitsDiskSizeGiB(sizeGiB int64) bool {
+	maxSize, err := s.MaxSizeGiB()
+	if err != nil || sizeGiB > maxSize {
+		return false
+	}
+	minSize, err := s.MinSizeGiB()
+	return err != nil || sizeGiB >= minSize
+}
+
+// GetDisks returns the list of all disk skus in a given azure location.
+// Zone availability is reported by AvailabilityZones as for virtual
+// machines.
+func (c *Cache) GetDisks(ctx context.Context) []SKU {
+	return c.ofType(ctx, Disks)
+}
@@ -0,0 +1,73 @@
+package skewer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-03-01/compute" //nolint:staticcheck
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_Disks(t *testing.T) {
+	p30 := compute.ResourceSku{
+		ResourceType: to.StringPtr(Disks),
+		Name:         to.StringPtr("Premium_LRS"),
+		Tier:         to.StringPtr("Premium"),
+		Size:         to.StringPtr("P30"),
+		Locations:    &[]string{"eastus"},
+		LocationInfo: &[]compute.ResourceSkuLocationInfo{
+			{Location: to.StringPtr("eastus"), Zones: &[]string{"2", "1", "3"}},
+		},
+		Capabilities: &[]compute.ResourceSkuCapabilities{
+			{Name: to.StringPtr(CapabilityMaxSizeGiB), Value: to.StringPtr("1024")},
+			{Name: to.StringPtr(CapabilityMinSizeGiB), Value: to.StringPtr("513")},
+			{Name: to.StringPtr(CapabilityMaxIOps), Value: to.StringPtr("5000")},
+			{Name: to.StringPtr(CapabilityMinIOps), Value: to.StringPtr("5000")},
+			{Name: to.StringPtr(CapabilityMaxBandwidthMBps), Value: to.StringPtr("200")},
+			{Name: to.StringPtr(CapabilityMinBandwidthMBps), Value: to.StringPtr("200")},
+		},
+	}
+	vm := compute.ResourceSku{
+		ResourceType: to.StringPtr(VirtualMachines),
+		Name:         to.StringPtr("Standard_D4s_v3"),
+		Locations:    &[]string{"eastus"},
+	}
+
+	cache, err := NewStaticCache(Wrap([]compute.ResourceSku{p30, vm}), WithLocation("eastus"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	disks := cache.GetDisks(context.Background())
+	if len(disks) != 1 {
+		t.Fatalf("expected 1 disk sku, got %d", len(disks))
+	}
+	disk := disks[0]
+
+	for name, getter := range map[string]func() (int64, error){
+		"max size":      disk.MaxSizeGiB,
+		"min size":      disk.MinSizeGiB,
+		"max iops":      disk.MaxIOPS,
+		"max bandwidth": disk.MaxBandwidthMBps,
+	} {
+		if _, err := getter(); err != nil {
+			t.Errorf("expected %s, got %s", name, err)
+		}
+	}
+	if got, _ := disk.MaxIOPS(); got != 5000 {
+		t.Errorf("expected 5000 iops, got %d", got)
+	}
+	if diff := cmp.Diff([]string{"1", "2", "3"}, disk.AvailabilityZoneList("eastus")); diff != "" {
+		t.Error(diff)
+	}
+
+	for size, expect := range map[int64]bool{512: false, 513: true, 1024: true, 1025: false} {
+		if got := disk.FitsDiskSizeGiB(size); got != expect {
+			t.Errorf("expected %d GiB to fit %t, got %t", size, expect, got)
+		}
+	}
+	other := SKU(vm)
+	if other.FitsDiskSizeGiB(1) {
+		t.Error("expected virtual machine sku not to fit disks")
+	}
+}
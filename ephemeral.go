@@ -0,0 +1,60 @@
+package skewer
+
+import "strings"
+
+// EphemeralOSDiskPlacements returns the placements supported for
+// ephemeral os disks on this VM size. Sizes which support ephemeral os
+// disks without reporting SupportedEphemeralOSDiskPlacements support
+// the cache and temporary disks they have.
+func (s *SKU) EphemeralOSDiskPlacements() map[EphemeralOSDiskPlacement]bool {
+	if !s.IsEphemeralOSDiskSupported() {
+		return nil
+	}
+
+	placements := make(map[EphemeralOSDiskPlacement]bool)
+	if value, err := s.GetCapabilityString(CapabilitySupportedEphemeralOSDiskPlacements); err == nil {
+		for _, item := range strings.Split(value, ",") {
+			for _, placement := range []EphemeralOSDiskPlacement{
+				EphemeralOSDiskPlacementCacheDisk,
+				EphemeralOSDiskPlacementResourceDisk,
+				EphemeralOSDiskPlacementNvmeDisk,
+			} {
+				if strings.EqualFold(strings.TrimSpace(item), string(placement)) {
+					placements[placement] = true
+				}
+			}
+		}
+		return placements
+	}
+
+	if bytes, err := s.MaxCachedDiskBytes(); err == nil && bytes > 0 {
+		placements[EphemeralOSDiskPlacementCacheDisk] = true
+	}
+	if mb, err := s.MaxResourceVolumeMB(); err == nil && mb > 0 {
+		placements[EphemeralOSDiskPlacementResourceDisk] = true
+	}
+	return placements
+}
+
+// CanFitEphemeralOSDisk returns true when an ephemeral os disk of
+// sizeGiB can be placed on the placement of this VM size: the placement
+// must be supported and its disk large enough.
+func (s *SKU) CanFitEphemeralOSDisk(sizeGiB int64, placement EphemeralOSDiskPlacement) bool {
+	if !s.EphemeralOSDiskPlacements()[placement] {
+		return false
+	}
+
+	sizeMiB := sizeGiB * mebibytesPerGiB
+	switch placement {
+	case EphemeralOSDiskPlacementCacheDisk:
+		bytes, err := s.MaxCachedDiskBytes()
+		return err == nil && bytes >= sizeMiB*bytesPerMiB
+	case EphemeralOSDiskPlacementResourceDisk:
+		mb, err := s.MaxResourceVolumeMB()
+		return err == nil && mb >= sizeMiB
+	case EphemeralOSDiskPlacementNvmeDisk:
+		mib, err := s.NvmeDiskSizeInMiB()
+		return err == nil && mib >= sizeMiB
+	}
+	return false
+}
@@ -0,0 +1,90 @@
+package skewer
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-03-01/compute" //nolint:staticcheck
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_SKU_EphemeralOSDiskPlacements(t *testing.T) {
+	capability := func(name, value string) compute.ResourceSkuCapabilities {
+		return compute.ResourceSkuCapabilities{Name: to.StringPtr(name), Value: to.StringPtr(value)}
+	}
+	legacy := SKU{Capabilities: &[]compute.ResourceSkuCapabilities{
+		capability(EphemeralOSDisk, "True"),
+		capability(CachedDiskBytes, "107374182400"),
+		capability(MaxResourceVolumeMB, "32768"),
+	}}
+	nvme := SKU{Capabilities: &[]compute.ResourceSkuCapabilities{
+		capability(EphemeralOSDisk, "True"),
+		capability(CapabilitySupportedEphemeralOSDiskPlacements, "ResourceDisk, NvmeDisk"),
+		capability(MaxResourceVolumeMB, "0"),
+		capability(CapabilityNvmeDiskSizeInMiB, "225280"),
+	}}
+
+	cases := map[string]struct {
+		sku       SKU
+		expect    map[EphemeralOSDiskPlacement]bool
+		sizeGiB   int64
+		placement EphemeralOSDiskPlacement
+		fits      bool
+	}{
+		"unsupported ephemeral os disk should have no placements": {
+			sku:       SKU{},
+			sizeGiB:   30,
+			placement: EphemeralOSDiskPlacementCacheDisk,
+		},
+		"legacy sku should fit on cache disk": {
+			sku: legacy,
+			expect: map[EphemeralOSDiskPlacement]bool{
+				EphemeralOSDiskPlacementCacheDisk:    true,
+				EphemeralOSDiskPlacementResourceDisk: true,
+			},
+			sizeGiB:   100,
+			placement: EphemeralOSDiskPlacementCacheDisk,
+			fits:      true,
+		},
+		"legacy sku should not fit large disk on resource disk": {
+			sku: legacy,
+			expect: map[EphemeralOSDiskPlacement]bool{
+				EphemeralOSDiskPlacementCacheDisk:    true,
+				EphemeralOSDiskPlacementResourceDisk: true,
+			},
+			sizeGiB:   33,
+			placement: EphemeralOSDiskPlacementResourceDisk,
+		},
+		"nvme sku should fit on nvme disk": {
+			sku: nvme,
+			expect: map[EphemeralOSDiskPlacement]bool{
+				EphemeralOSDiskPlacementResourceDisk: true,
+				EphemeralOSDiskPlacementNvmeDisk:     true,
+			},
+			sizeGiB:   128,
+			placement: EphemeralOSDiskPlacementNvmeDisk,
+			fits:      true,
+		},
+		"nvme sku should not fit unsupported placement": {
+			sku: nvme,
+			expect: map[EphemeralOSDiskPlacement]bool{
+				EphemeralOSDiskPlacementResourceDisk: true,
+				EphemeralOSDiskPlacementNvmeDisk:     true,
+			},
+			sizeGiB:   30,
+			placement: EphemeralOSDiskPlacementCacheDisk,
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.expect, tc.sku.EphemeralOSDiskPlacements()); diff != "" {
+				t.Error(diff)
+			}
+			if diff := cmp.Diff(tc.fits, tc.sku.CanFitEphemeralOSDisk(tc.sizeGiB, tc.placement)); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
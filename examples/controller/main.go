@@ -0,0 +1,130 @@
+// Command controller is a sample controller built on skewer. It keeps a
+// cache of the resource skus of a location fresh in the background and
+// periodically reports the availability zones each desired VM size can
+// be scheduled in, across throttling and transient listing failures.
+//
+// Usage:
+//
+//	controller -subscription <id> -location <location> -sizes Standard_D2_v2,Standard_D4s_v3 [-interval 10m] [-endpoint <url>]
+//
+// The endpoint defaults to Azure Resource Manager and authenticates with
+// the Azure CLI. Any other endpoint, e.g. a skewertest server, is used
+// without authentication.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-03-01/compute" //nolint:staticcheck
+	"github.com/Azure/go-autorest/autorest/azure/auth"
+	"github.com/Azure/skewer"
+)
+
+const defaultInterval = 10 * time.Minute
+
+func main() {
+	subscriptionID := flag.String("subscription", "", "subscription id to list skus of")
+	location := flag.String("location", "", "location to schedule in")
+	sizes := flag.String("sizes", "", "comma separated VM sizes to report on")
+	interval := flag.Duration("interval", defaultInterval, "interval between refreshes")
+	endpoint := flag.String("endpoint", compute.DefaultBaseURI, "resource manager endpoint")
+	flag.Parse()
+
+	if *subscriptionID == "" || *location == "" || *sizes == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	client := compute.NewResourceSkusClientWithBaseURI(*endpoint, *subscriptionID)
+	if *endpoint == compute.DefaultBaseURI {
+		authorizer, err := auth.NewAuthorizerFromCLI()
+		if err != nil {
+			log.Fatal(err)
+		}
+		client.Authorizer = authorizer
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	c, err := newController(ctx, client, *location, strings.Split(*sizes, ","))
+	if err != nil {
+		log.Fatal(err)
+	}
+	c.run(ctx, *interval, func(report map[string][]string) {
+		for _, size := range c.sizes {
+			fmt.Printf("%s: %v\n", size, report[size])
+		}
+	})
+}
+
+// controller reconciles desired VM sizes against a sku cache.
+type controller struct {
+	cache    *skewer.Cache
+	location string
+	sizes    []string
+}
+
+// newController builds the cache, failing when the first listing fails
+// since the controller has nothing to schedule with.
+func newController(ctx context.Context, client skewer.ResourceClient, location string, sizes []string) (*controller, error) {
+	cache, err := skewer.NewCache(ctx, skewer.WithResourceClient(client), skewer.WithLocation(location))
+	if err != nil {
+		return nil, err
+	}
+	return &controller{cache: cache, location: location, sizes: sizes}, nil
+}
+
+// reconcile returns the available zones of each desired size which is
+// available in the location. Sizes which are unknown or restricted are
+// omitted.
+func (c *controller) reconcile(ctx context.Context) map[string][]string {
+	available := c.cache.List(ctx, skewer.ResourceTypeFilter(skewer.VirtualMachines), func(sku *skewer.SKU) bool {
+		for _, size := range c.sizes {
+			if strings.EqualFold(sku.GetName(), size) {
+				return sku.IsAvailable(c.location)
+			}
+		}
+		return false
+	})
+
+	report := make(map[string][]string, len(available))
+	for i := range available {
+		var zones []string
+		for zone := range available[i].AvailabilityZones(c.location) {
+			zones = append(zones, zone)
+		}
+		sort.Strings(zones)
+		report[available[i].GetName()] = zones
+	}
+	return report
+}
+
+// run refreshes the cache and reports every interval until the context
+// is done. A failed refresh keeps the previous data, so reports stay
+// consistent with the last complete listing.
+func (c *controller) run(ctx context.Context, interval time.Duration, report func(map[string][]string)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	report(c.reconcile(ctx))
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := c.cache.Refresh(ctx); err != nil {
+				log.Printf("refresh failed, keeping previous skus: %s", err)
+			}
+			report(c.reconcile(ctx))
+		}
+	}
+}
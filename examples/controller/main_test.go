@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-03-01/compute" //nolint:staticcheck
+	"github.com/Azure/skewer/skewertest"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_Controller(t *testing.T) {
+	data, err := os.ReadFile("../../testdata/eastus.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var wrapper struct {
+		Value []compute.ResourceSku `json:"value"`
+	}
+	if err := json.Unmarshal(data, &wrapper); err != nil {
+		t.Fatal(err)
+	}
+
+	server := skewertest.NewServer(wrapper.Value, 1)
+	defer server.Close()
+	server.Throttle(1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c, err := newController(ctx, server.Client("subscription"), "eastus", []string{"Standard_D2_v2", "standard_nv6", "Standard_Unknown"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Standard_NV6 is restricted in all zones, but available regionally.
+	expected := map[string][]string{"Standard_D2_v2": {"1", "2", "3"}, "Standard_NV6": nil}
+	if diff := cmp.Diff(expected, c.reconcile(ctx)); diff != "" {
+		t.Error(diff)
+	}
+
+	// Reports must survive a partial listing, then pick up new data.
+	server.FailPage(1)
+	reports := make(chan map[string][]string)
+	go c.run(ctx, time.Millisecond, func(report map[string][]string) {
+		select {
+		case reports <- report:
+		case <-ctx.Done():
+		}
+	})
+	for i := 0; i < 3; i++ {
+		if diff := cmp.Diff(expected, <-reports); diff != "" {
+			t.Error(diff)
+		}
+	}
+
+	server.FailPage(-1)
+	server.SetSKUs(wrapper.Value[1:])
+	for report := range reports {
+		if _, ok := report["Standard_D2_v2"]; !ok {
+			cancel()
+			break
+		}
+	}
+}
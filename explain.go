@@ -0,0 +1,40 @@
+package skewer
+
+import "context"
+
+// Predicate is a named filter, so the reason a sku is rejected can be
+// explained, e.g. Predicate{"premium io", (*SKU).IsPremiumIO}.
+type Predicate struct {
+	Name   string
+	Filter FilterFn
+}
+
+// Rejection records the first predicate which eliminated a sku.
+type Rejection struct {
+	SKU       string
+	Predicate string
+}
+
+// Explain filters the cache like List, and additionally returns, for
+// every rejected sku, the first predicate it failed. It helps debugging
+// why no sku matched a complex set of filters.
+func (c *Cache) Explain(ctx context.Context, predicates ...Predicate) ([]SKU, []Rejection) {
+	var matched []SKU
+	var rejections []Rejection
+	data := c.skus(ctx)
+	for i := range data {
+		sku := &data[i]
+		rejected := false
+		for _, predicate := range predicates {
+			if !predicate.Filter(sku) {
+				rejections = append(rejections, Rejection{SKU: sku.GetName(), Predicate: predicate.Name})
+				rejected = true
+				break
+			}
+		}
+		if !rejected {
+			matched = append(matched, *sku)
+		}
+	}
+	return matched, rejections
+}
@@ -0,0 +1,38 @@
+package skewer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_Cache_Explain(t *testing.T) {
+	dataWrapper, err := newDataWrapper("./testdata/eastus.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache, err := NewStaticCache(Wrap(dataWrapper.Value), WithLocation("eastus"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	matched, rejections := cache.Explain(context.Background(),
+		Predicate{"available", func(s *SKU) bool { return s.IsAvailable("eastus") }},
+		Predicate{"memory", minimumFilter((*SKU).MemoryMiB, 8*mebibytesPerGiB)},
+		Predicate{"premium io", (*SKU).IsPremiumIO},
+	)
+
+	if diff := cmp.Diff([]string{"Standard_D4s_v3"}, skuNames(matched)); diff != "" {
+		t.Error(diff)
+	}
+
+	expect := []Rejection{
+		{SKU: "Standard_D13_v2_Promo", Predicate: "available"},
+		{SKU: "Standard_D2_v2", Predicate: "memory"},
+		{SKU: "Standard_NV6", Predicate: "premium io"},
+	}
+	if diff := cmp.Diff(expect, rejections); diff != "" {
+		t.Error(diff)
+	}
+}
@@ -188,3 +188,12 @@ func (p *pageList) next(context.Context, compute.ResourceSkusResult) (compute.Re
 	p.cursor++
 	return p.pages[old], nil
 }
+
+// skuNames returns the names of the skus in order, or nil for no skus.
+func skuNames(skus []SKU) []string {
+	var names []string
+	for i := range skus {
+		names = append(names, skus[i].GetName())
+	}
+	return names
+}
@@ -0,0 +1,157 @@
+package skewer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// featureStateRegistered is the state reported by the features API once
+// a subscription has been registered for a feature.
+const featureStateRegistered = "Registered"
+
+// Feature identifies a subscription feature registration (AFEC flag),
+// for example "Microsoft.Compute/EncryptionAtHost".
+type Feature struct {
+	Namespace string
+	Name      string
+}
+
+func (f Feature) String() string {
+	return f.Namespace + "/" + f.Name
+}
+
+// FeatureRequirement maps SKUs to a subscription feature they require.
+// A requirement applies to a SKU belonging to Family, or to a SKU
+// supporting Capability. Empty fields never match.
+type FeatureRequirement struct {
+	Family     string
+	Capability string
+	Feature    Feature
+}
+
+func (r FeatureRequirement) appliesTo(s *SKU) bool {
+	if r.Family != "" && strings.EqualFold(s.GetFamilyName(), r.Family) {
+		return true
+	}
+	return r.Capability != "" && s.HasCapability(r.Capability)
+}
+
+// defaultFeatureRequirements is the maintained mapping of skus to the
+// subscription features they require.
+// See https://learn.microsoft.com/en-us/azure/virtual-machines/disks-enable-host-based-encryption-portal
+var defaultFeatureRequirements = []FeatureRequirement{
+	{
+		Capability: EncryptionAtHost,
+		Feature:    Feature{Namespace: "Microsoft.Compute", Name: "EncryptionAtHost"},
+	},
+}
+
+// DefaultFeatureRequirements returns a copy of the maintained mapping
+// of skus to subscription features used by IsDeployable when no
+// requirements are configured. It maps sizes supporting encryption at
+// host to the Microsoft.Compute/EncryptionAtHost registration, which
+// ARM requires before a VM enables it.
+func DefaultFeatureRequirements() []FeatureRequirement {
+	return append([]FeatureRequirement(nil), defaultFeatureRequirements...)
+}
+
+// RequiredFeatures returns the features which the given requirements
+// demand for the provided sku, without duplicates.
+func RequiredFeatures(sku *SKU, requirements []FeatureRequirement) []Feature {
+	var result []Feature
+	seen := make(map[Feature]bool)
+	for _, requirement := range requirements {
+		if seen[requirement.Feature] || !requirement.appliesTo(sku) {
+			continue
+		}
+		seen[requirement.Feature] = true
+		result = append(result, requirement.Feature)
+	}
+	return result
+}
+
+// ErrFeatureNotRegistered will be returned when a sku requires
+// subscription features which are not registered.
+type ErrFeatureNotRegistered struct {
+	Name     string
+	Features []Feature
+}
+
+func (e *ErrFeatureNotRegistered) Error() string {
+	names := make([]string, 0, len(e.Features))
+	for _, feature := range e.Features {
+		names = append(names, feature.String())
+	}
+	return fmt.Sprintf("sku %s requires unregistered subscription features: %s", e.Name, strings.Join(names, ", "))
+}
+
+// WithFeatureClient is a functional option to check subscription
+// feature registrations with the provided client in IsDeployable.
+func WithFeatureClient(client FeatureClient) Option {
+	return func(c *Config) (*Config, error) {
+		c.featureClient = client
+		return c, nil
+	}
+}
+
+// WithFeatureRequirements is a functional option to declare which
+// subscription features SKUs require to be deployed, replacing
+// DefaultFeatureRequirements.
+func WithFeatureRequirements(requirements ...FeatureRequirement) Option {
+	return func(c *Config) (*Config, error) {
+		c.featureRequirements = append(c.featureRequirements, requirements...)
+		return c, nil
+	}
+}
+
+// IsDeployable returns true when the sku is available in the cache
+// location, or its own location when the cache has none, and, if a
+// feature client was provided, the subscription has registered every
+// feature the sku requires. Missing registrations are reported with
+// ErrFeatureNotRegistered. Without a cache location, skus without a
+// single location return the error of GetLocation.
+func (c *Cache) IsDeployable(ctx context.Context, sku *SKU) (bool, error) {
+	location := c.config.location
+	if location == "" {
+		var err error
+		if location, err = sku.GetLocation(); err != nil {
+			return false, err
+		}
+	}
+
+	if !sku.IsAvailableWithZones(location) {
+		return false, nil
+	}
+
+	if c.config.featureClient == nil {
+		return true, nil
+	}
+
+	var missing []Feature
+	requirements := c.config.featureRequirements
+	if len(requirements) == 0 {
+		requirements = defaultFeatureRequirements
+	}
+	for _, feature := range RequiredFeatures(sku, requirements) {
+		result, err := c.config.featureClient.Get(ctx, feature.Namespace, feature.Name)
+		if err != nil {
+			return false, errors.Wrapf(err, "could not get feature %s", feature)
+		}
+		if result.Properties == nil || result.Properties.State == nil ||
+			!strings.EqualFold(*result.Properties.State, featureStateRegistered) {
+			missing = append(missing, feature)
+		}
+	}
+
+	if len(missing) > 0 {
+		return false, &ErrFeatureNotRegistered{
+			Name:     sku.GetName(),
+			Features: missing,
+		}
+	}
+
+	return true, nil
+}
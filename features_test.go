@@ -0,0 +1,199 @@
+package skewer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-03-01/compute" //nolint:staticcheck
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2021-07-01/features"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/google/go-cmp/cmp"
+)
+
+// fakeFeatureClient reports every feature in registered as registered.
+type fakeFeatureClient struct {
+	registered map[string]bool
+}
+
+func (f *fakeFeatureClient) Get(ctx context.Context, resourceProviderNamespace, featureName string) (features.Result, error) {
+	state := "NotRegistered"
+	if f.registered[resourceProviderNamespace+"/"+featureName] {
+		state = featureStateRegistered
+	}
+	return features.Result{
+		Properties: &features.Properties{
+			State: to.StringPtr(state),
+		},
+	}, nil
+}
+
+func Test_RequiredFeatures(t *testing.T) {
+	preview := Feature{Namespace: "Microsoft.Compute", Name: "Preview"}
+	encryption := Feature{Namespace: "Microsoft.Compute", Name: "EncryptionAtHost"}
+
+	cases := map[string]struct {
+		sku          compute.ResourceSku
+		requirements []FeatureRequirement
+		expect       []Feature
+	}{
+		"no requirements should require nothing": {
+			sku: compute.ResourceSku{Family: to.StringPtr("fooFamily")},
+		},
+		"should match family regardless of case": {
+			sku: compute.ResourceSku{Family: to.StringPtr("fooFamily")},
+			requirements: []FeatureRequirement{
+				{Family: "FOOFAMILY", Feature: preview},
+				{Family: "barFamily", Feature: encryption},
+			},
+			expect: []Feature{preview},
+		},
+		"should match supported capability once": {
+			sku: compute.ResourceSku{
+				Capabilities: &[]compute.ResourceSkuCapabilities{
+					{
+						Name:  to.StringPtr(EncryptionAtHost),
+						Value: to.StringPtr("True"),
+					},
+				},
+			},
+			requirements: []FeatureRequirement{
+				{Capability: EncryptionAtHost, Feature: encryption},
+				{Capability: EncryptionAtHost, Feature: encryption},
+			},
+			expect: []Feature{encryption},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			sku := SKU(tc.sku)
+			if diff := cmp.Diff(tc.expect, RequiredFeatures(&sku, tc.requirements)); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func Test_Cache_IsDeployable(t *testing.T) {
+	preview := Feature{Namespace: "Microsoft.Compute", Name: "Preview"}
+	sku := SKU{
+		Name:      to.StringPtr("foo"),
+		Family:    to.StringPtr("fooFamily"),
+		Locations: &[]string{"baz"},
+		LocationInfo: &[]compute.ResourceSkuLocationInfo{
+			{
+				Location: to.StringPtr("baz"),
+			},
+		},
+	}
+
+	cases := map[string]struct {
+		options    []Option
+		expect     bool
+		unregister bool
+	}{
+		"should be deployable without feature client": {
+			options: []Option{WithFeatureRequirements(FeatureRequirement{Family: "fooFamily", Feature: preview})},
+			expect:  true,
+		},
+		"should be deployable with registered feature": {
+			options: []Option{
+				WithFeatureRequirements(FeatureRequirement{Family: "fooFamily", Feature: preview}),
+				WithFeatureClient(&fakeFeatureClient{registered: map[string]bool{preview.String(): true}}),
+			},
+			expect: true,
+		},
+		"should not be deployable with unregistered feature": {
+			options: []Option{
+				WithFeatureRequirements(FeatureRequirement{Family: "fooFamily", Feature: preview}),
+				WithFeatureClient(&fakeFeatureClient{}),
+			},
+			unregister: true,
+		},
+		"should be deployable without default requirements applying": {
+			options: []Option{WithFeatureClient(&fakeFeatureClient{})},
+			expect:  true,
+		},
+		"should not be deployable in other location": {
+			options: []Option{WithLocation("other")},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			cache, err := NewStaticCache([]SKU{sku}, tc.options...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := cache.IsDeployable(context.Background(), &sku)
+			var errNotRegistered *ErrFeatureNotRegistered
+			if tc.unregister != errors.As(err, &errNotRegistered) {
+				t.Errorf("expected unregistered feature error %t, got '%v'", tc.unregister, err)
+			}
+			if diff := cmp.Diff(tc.expect, got); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func Test_Cache_IsDeployable_DefaultFeatureRequirements(t *testing.T) {
+	encryptionAtHost := Feature{Namespace: "Microsoft.Compute", Name: "EncryptionAtHost"}
+	sku := SKU{
+		Name:      to.StringPtr("foo"),
+		Locations: &[]string{"baz"},
+		LocationInfo: &[]compute.ResourceSkuLocationInfo{
+			{
+				Location: to.StringPtr("baz"),
+			},
+		},
+		Capabilities: &[]compute.ResourceSkuCapabilities{
+			{Name: to.StringPtr(EncryptionAtHost), Value: to.StringPtr(string(CapabilitySupported))},
+		},
+	}
+
+	if diff := cmp.Diff([]Feature{encryptionAtHost}, RequiredFeatures(&sku, DefaultFeatureRequirements())); diff != "" {
+		t.Errorf("expected default requirements to require encryption at host: %s", diff)
+	}
+
+	cache, err := NewStaticCache([]SKU{sku}, WithFeatureClient(&fakeFeatureClient{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var errNotRegistered *ErrFeatureNotRegistered
+	if ok, err := cache.IsDeployable(context.Background(), &sku); ok || !errors.As(err, &errNotRegistered) {
+		t.Errorf("expected default requirements to be checked, got %t, '%v'", ok, err)
+	}
+
+	cache, err = NewStaticCache([]SKU{sku},
+		WithFeatureClient(&fakeFeatureClient{registered: map[string]bool{encryptionAtHost.String(): true}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := cache.IsDeployable(context.Background(), &sku); !ok || err != nil {
+		t.Errorf("expected registered default feature to be deployable, got %t, '%v'", ok, err)
+	}
+
+	cache, err = NewStaticCache([]SKU{sku}, WithFeatureClient(&fakeFeatureClient{}),
+		WithFeatureRequirements(FeatureRequirement{Family: "barFamily", Feature: encryptionAtHost}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := cache.IsDeployable(context.Background(), &sku); !ok || err != nil {
+		t.Errorf("expected configured requirements to replace the defaults, got %t, '%v'", ok, err)
+	}
+}
+
+func Test_Cache_IsDeployable_NilLocation(t *testing.T) {
+	sku := SKU{Name: to.StringPtr("foo")}
+	cache, err := NewStaticCache([]SKU{sku})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := cache.IsDeployable(context.Background(), &sku); ok || err == nil {
+		t.Errorf("expected location error for sku without location, got %t, '%v'", ok, err)
+	}
+}
@@ -0,0 +1,61 @@
+package skewer
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// NewerGenerations returns the virtual machine skus of the cache which
+// are newer versions of this size, available in the same location,
+// ordered from oldest to newest. A newer generation has the same
+// family, sub-family, vCPUs, additive features and accelerator, and a
+// higher version, e.g. Standard_D8s_v4 and Standard_D8s_v5 for
+// Standard_D8s_v3. Promotional sizes are never suggested.
+func (s *SKU) NewerGenerations(ctx context.Context, cache *Cache) []SKU {
+	current, err := s.GetVMSize()
+	if err != nil {
+		return nil
+	}
+	location := cache.skuLocation(s)
+	version := versionNumber(current.version)
+
+	result := cache.List(ctx, ResourceTypeFilter(VirtualMachines), func(candidate *SKU) bool {
+		vmSize, err := candidate.GetVMSize()
+		if err != nil || vmSize.promoVersion || versionNumber(vmSize.version) <= version || !sameSize(current, vmSize) {
+			return false
+		}
+		if cache.config.includeRestricted {
+			return candidate.HasLocation(location)
+		}
+		return candidate.IsAvailableWithZones(location)
+	})
+	sort.SliceStable(result, func(i, j int) bool {
+		a, _ := result[i].GetVMSize()
+		b, _ := result[j].GetVMSize()
+		return versionNumber(a.version) < versionNumber(b.version)
+	})
+	return result
+}
+
+// sameSize returns true when both sizes only differ by version.
+func sameSize(a, b *VMSizeType) bool {
+	return a.family == b.family &&
+		a.Subfamily() == b.Subfamily() &&
+		a.cpus == b.cpus &&
+		stringPtrEqual(a.cpusConstrained, b.cpusConstrained) &&
+		string(a.additiveFeatures) == string(b.additiveFeatures) &&
+		strings.EqualFold(a.AcceleratorType(), b.AcceleratorType()) &&
+		a.confidentialChildCapability == b.confidentialChildCapability
+}
+
+// versionNumber returns the number of a version such as "v3", treating
+// sizes without a version as the first generation.
+func versionNumber(version string) int {
+	number, err := strconv.Atoi(strings.TrimLeft(version, "vV"))
+	if err != nil {
+		return 1
+	}
+	return number
+}
@@ -0,0 +1,75 @@
+package skewer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-03-01/compute" //nolint:staticcheck
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_SKU_NewerGenerations(t *testing.T) {
+	ctx := context.Background()
+	vm := func(name, size, location string) compute.ResourceSku {
+		return compute.ResourceSku{
+			Name:         to.StringPtr(name),
+			Size:         to.StringPtr(size),
+			ResourceType: to.StringPtr(VirtualMachines),
+			Locations:    &[]string{location},
+			LocationInfo: &[]compute.ResourceSkuLocationInfo{{Location: to.StringPtr(location)}},
+		}
+	}
+	restricted := vm("Standard_D8s_v6", "D8s_v6", "eastus")
+	restricted.Restrictions = &[]compute.ResourceSkuRestrictions{
+		{Type: compute.Location, Values: &[]string{"eastus"}},
+	}
+
+	cache, err := NewStaticCache(Wrap([]compute.ResourceSku{
+		vm("Standard_D8s_v3", "D8s_v3", "eastus"),
+		vm("Standard_D8s_v5", "D8s_v5", "eastus"),
+		vm("Standard_D8s_v4", "D8s_v4", "eastus"),
+		vm("Standard_D8as_v5", "D8as_v5", "eastus"),
+		vm("Standard_D16s_v5", "D16s_v5", "eastus"),
+		vm("Standard_D8s_v4_Promo", "D8s_v4_Promo", "eastus"),
+		vm("Standard_D8s_v5", "D8s_v5", "westus"),
+		vm("Standard_D8", "D8", "eastus"),
+		restricted,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]struct {
+		size     string
+		location string
+		expect   []string
+	}{
+		"should suggest newer versions in the same location": {
+			size:     "Standard_D8s_v3",
+			location: "eastus",
+			expect:   []string{"Standard_D8s_v4", "Standard_D8s_v5"},
+		},
+		"should treat unversioned sizes as first generation": {
+			size:     "Standard_D8",
+			location: "eastus",
+		},
+		"should not suggest anything for the newest generation": {
+			size:     "Standard_D8s_v5",
+			location: "westus",
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			sku, err := cache.Get(ctx, tc.size, VirtualMachines, tc.location)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.expect, skuNames(sku.NewerGenerations(ctx, cache))); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
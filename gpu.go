@@ -0,0 +1,137 @@
+package skewer
+
+import "strings"
+
+const (
+	// GPUVendorNVIDIA identifies NVIDIA GPUs.
+	GPUVendorNVIDIA = "NVIDIA"
+	// GPUVendorAMD identifies AMD GPUs.
+	GPUVendorAMD = "AMD"
+)
+
+// GPUInfo describes the GPU model of a GPU-bearing VM size.
+type GPUInfo struct {
+	Vendor string
+	Model  string
+}
+
+// gpuAccelerators maps the accelerator type of a VM size name, e.g. the
+// "T4" in "NC4as_T4_v3", to its GPU.
+var gpuAccelerators = map[string]GPUInfo{
+	"T4":   {GPUVendorNVIDIA, "Tesla T4"},
+	"A10":  {GPUVendorNVIDIA, "A10"},
+	"A100": {GPUVendorNVIDIA, "A100"},
+	"H100": {GPUVendorNVIDIA, "H100"},
+	"V620": {GPUVendorAMD, "Radeon PRO V620"},
+}
+
+// gpuSeries maps GPU series without an accelerator type in their name,
+// keyed by family, sub-family and version, to their GPU.
+// See https://learn.microsoft.com/en-us/azure/virtual-machines/sizes-gpu
+var gpuSeries = map[string]GPUInfo{
+	"NC":    {GPUVendorNVIDIA, "Tesla K80"},
+	"NC_v2": {GPUVendorNVIDIA, "Tesla P100"},
+	"NC_v3": {GPUVendorNVIDIA, "Tesla V100"},
+	"ND":    {GPUVendorNVIDIA, "Tesla P40"},
+	"ND_v2": {GPUVendorNVIDIA, "Tesla V100"},
+	"ND_v4": {GPUVendorNVIDIA, "A100"},
+	"NV":    {GPUVendorNVIDIA, "Tesla M60"},
+	"NV_v3": {GPUVendorNVIDIA, "Tesla M60"},
+	"NV_v4": {GPUVendorAMD, "Radeon Instinct MI25"},
+}
+
+// GetGPUInfo returns the GPU vendor and model of the VM size, derived
+// from its name since the API only exposes the GPU count (see GPU). The
+// boolean is false when the size is not a known GPU size. See
+// SetNameMetadataProvider to teach skewer about new sizes.
+func (s *SKU) GetGPUInfo() (GPUInfo, bool) {
+	vmSize, err := s.GetVMSize()
+	if err != nil {
+		return GPUInfo{}, false
+	}
+	acceleratorType := ""
+	if vmSize.acceleratorType != nil {
+		acceleratorType = *vmSize.acceleratorType
+	}
+	return lookupGPU(acceleratorType, vmSize.seriesKey())
+}
+
+// GPUVendorFilter produces a filter function matching GPU skus of the
+// provided vendor, e.g. GPUVendorNVIDIA.
+func GPUVendorFilter(vendor string) func(*SKU) bool {
+	return func(s *SKU) bool {
+		info, ok := s.GetGPUInfo()
+		return ok && strings.EqualFold(info.Vendor, vendor)
+	}
+}
+
+// GPUPartitioning describes how the GPUs of a VM size may be shared.
+type GPUPartitioning struct {
+	// MIG is true when the GPUs support NVIDIA multi-instance GPU.
+	MIG bool
+	// MIGProfiles lists the compute slices of the supported MIG
+	// profiles, e.g. "1g". The memory of each profile depends on the
+	// memory of the GPU.
+	MIGProfiles []string
+	// Fraction is the share of a single GPU assigned to a fractional,
+	// vGPU backed VM size, e.g. 0.5, and 0 for sizes with whole GPUs.
+	Fraction float64
+}
+
+// migProfiles maps GPU models supporting multi-instance GPU to the
+// compute slices of their profiles.
+// See https://docs.nvidia.com/datacenter/tesla/mig-user-guide
+var migProfiles = map[string][]string{
+	"A100": {"1g", "2g", "3g", "4g", "7g"},
+	"H100": {"1g", "2g", "3g", "4g", "7g"},
+}
+
+// gpuPartitions maps fractional GPU VM sizes, keyed by lower case size,
+// to the number of VMs a single GPU is partitioned into.
+// See https://learn.microsoft.com/en-us/azure/virtual-machines/nva10v5-series,
+// https://learn.microsoft.com/en-us/azure/virtual-machines/nvv4-series
+// and https://learn.microsoft.com/en-us/azure/virtual-machines/ngads-v-620-series
+var gpuPartitions = map[string]int64{
+	"nv6ads_a10_v5":   6,
+	"nv12ads_a10_v5":  3,
+	"nv18ads_a10_v5":  2,
+	"nv4as_v4":        8,
+	"nv8as_v4":        4,
+	"nv16as_v4":       2,
+	"ng8ads_v620_v1":  4,
+	"ng16ads_v620_v1": 2,
+}
+
+// GetGPUPartitioning returns whether the GPUs of the VM size support
+// multi-instance GPU or are fractional vGPUs, derived from maintained
+// tables since the API does not expose it. The boolean is false when
+// the size is not a known GPU size.
+func (s *SKU) GetGPUPartitioning() (GPUPartitioning, bool) {
+	info, ok := s.GetGPUInfo()
+	if !ok {
+		return GPUPartitioning{}, false
+	}
+	result := GPUPartitioning{}
+	if profiles, ok := migProfiles[info.Model]; ok {
+		result.MIG = true
+		result.MIGProfiles = append([]string(nil), profiles...)
+	}
+	if partitions, ok := gpuPartitions[strings.ToLower(s.GetSize())]; ok {
+		result.Fraction = 1 / float64(partitions)
+	}
+	return result, true
+}
+
+// IsMIGSupported returns true when the GPUs of the VM size support
+// NVIDIA multi-instance GPU.
+func (s *SKU) IsMIGSupported() bool {
+	partitioning, ok := s.GetGPUPartitioning()
+	return ok && partitioning.MIG
+}
+
+// IsFractionalGPU returns true when the VM size is assigned a fraction
+// of a GPU rather than whole GPUs.
+func (s *SKU) IsFractionalGPU() bool {
+	partitioning, ok := s.GetGPUPartitioning()
+	return ok && partitioning.Fraction > 0
+}
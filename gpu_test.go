@@ -0,0 +1,113 @@
+package skewer
+
+import (
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_SKU_GetGPUInfo(t *testing.T) {
+	cases := map[string]struct {
+		size   string
+		expect GPUInfo
+		found  bool
+	}{
+		"should not find gpu for general purpose size": {
+			size: "D4s_v3",
+		},
+		"should find gpu from accelerator type": {
+			size:   "NC4as_T4_v3",
+			expect: GPUInfo{Vendor: GPUVendorNVIDIA, Model: "Tesla T4"},
+			found:  true,
+		},
+		"should find gpu from series and version": {
+			size:   "NC6s_v3",
+			expect: GPUInfo{Vendor: GPUVendorNVIDIA, Model: "Tesla V100"},
+			found:  true,
+		},
+		"should find gpu from series without version": {
+			size:   "NV6",
+			expect: GPUInfo{Vendor: GPUVendorNVIDIA, Model: "Tesla M60"},
+			found:  true,
+		},
+		"should find amd gpu": {
+			size:   "NV4as_v4",
+			expect: GPUInfo{Vendor: GPUVendorAMD, Model: "Radeon Instinct MI25"},
+			found:  true,
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			sku := SKU{Size: to.StringPtr(tc.size)}
+			got, found := sku.GetGPUInfo()
+			if diff := cmp.Diff(tc.expect, got); diff != "" {
+				t.Error(diff)
+			}
+			if diff := cmp.Diff(tc.found, found); diff != "" {
+				t.Error(diff)
+			}
+			if diff := cmp.Diff(tc.found && tc.expect.Vendor == GPUVendorAMD, GPUVendorFilter("amd")(&sku)); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func Test_SKU_GetGPUPartitioning(t *testing.T) {
+	cases := map[string]struct {
+		size   string
+		expect GPUPartitioning
+		found  bool
+	}{
+		"should not find partitioning for general purpose size": {
+			size: "D4s_v3",
+		},
+		"should find whole gpus without partitioning": {
+			size:  "NC6s_v3",
+			found: true,
+		},
+		"should find mig support from accelerator type": {
+			size:   "NC24ads_A100_v4",
+			expect: GPUPartitioning{MIG: true, MIGProfiles: []string{"1g", "2g", "3g", "4g", "7g"}},
+			found:  true,
+		},
+		"should find mig support from series": {
+			size:   "ND96asr_v4",
+			expect: GPUPartitioning{MIG: true, MIGProfiles: []string{"1g", "2g", "3g", "4g", "7g"}},
+			found:  true,
+		},
+		"should find fractional nvidia gpu": {
+			size:   "NV18ads_A10_v5",
+			expect: GPUPartitioning{Fraction: 0.5},
+			found:  true,
+		},
+		"should find fractional amd gpu": {
+			size:   "NV4as_v4",
+			expect: GPUPartitioning{Fraction: 0.125},
+			found:  true,
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			sku := SKU{Size: to.StringPtr(tc.size)}
+			got, found := sku.GetGPUPartitioning()
+			if diff := cmp.Diff(tc.expect, got); diff != "" {
+				t.Error(diff)
+			}
+			if diff := cmp.Diff(tc.found, found); diff != "" {
+				t.Error(diff)
+			}
+			if diff := cmp.Diff(tc.expect.MIG, sku.IsMIGSupported()); diff != "" {
+				t.Error(diff)
+			}
+			if diff := cmp.Diff(tc.expect.Fraction > 0, sku.IsFractionalGPU()); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
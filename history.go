@@ -0,0 +1,96 @@
+package skewer
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// Snapshot is the sku data observed at a point in time.
+type Snapshot struct {
+	Time time.Time
+	SKUs []SKU
+}
+
+// CapabilityChange records a capability value differing between two
+// snapshots in which a sku was observed. Previous and Current are nil
+// when the capability was absent or had no value.
+type CapabilityChange struct {
+	ResourceType string
+	Name         string
+	Location     string
+	Capability   string
+	// Time is the time of the snapshot observing the new value.
+	Time     time.Time
+	Previous *string
+	Current  *string
+}
+
+// CapabilityHistory reports every change of a capability value for skus
+// matching all provided filters across a series of snapshots, for
+// example to detect a silent platform change of CachedDiskBytes.
+// Snapshots are compared in chronological order, and a sku missing from
+// a snapshot is compared against its last observation. Changes are
+// ordered by time, then resource type, name and location.
+func CapabilityHistory(snapshots []Snapshot, capability string, filters ...FilterFn) []CapabilityChange {
+	ordered := append([]Snapshot(nil), snapshots...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].Time.Before(ordered[j].Time)
+	})
+
+	type observation struct {
+		seen  bool
+		value *string
+	}
+
+	var changes []CapabilityChange
+	last := map[string]observation{}
+	for _, snapshot := range ordered {
+		var current []CapabilityChange
+		for _, sku := range Filter(snapshot.SKUs, filters...) {
+			sku := sku
+			location, _ := sku.GetLocation()
+			key := strings.ToLower(sku.GetResourceType()) + "/" + strings.ToLower(sku.GetName()) + "/" + normalizeLocation(location)
+
+			var value *string
+			if v, err := sku.GetCapabilityString(capability); err == nil {
+				value = &v
+			}
+
+			previous := last[key]
+			last[key] = observation{seen: true, value: value}
+			if !previous.seen || stringPtrEqual(previous.value, value) {
+				continue
+			}
+			current = append(current, CapabilityChange{
+				ResourceType: sku.GetResourceType(),
+				Name:         sku.GetName(),
+				Location:     location,
+				Capability:   capability,
+				Time:         snapshot.Time,
+				Previous:     previous.value,
+				Current:      value,
+			})
+		}
+		sort.SliceStable(current, func(i, j int) bool {
+			a, b := current[i], current[j]
+			if a.ResourceType != b.ResourceType {
+				return a.ResourceType < b.ResourceType
+			}
+			if a.Name != b.Name {
+				return a.Name < b.Name
+			}
+			return a.Location < b.Location
+		})
+		changes = append(changes, current...)
+	}
+
+	return changes
+}
+
+func stringPtrEqual(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
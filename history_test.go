@@ -0,0 +1,104 @@
+package skewer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-03-01/compute" //nolint:staticcheck
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_CapabilityHistory(t *testing.T) {
+	sku := func(name, cachedDiskBytes string) SKU {
+		s := SKU{
+			Name:         to.StringPtr(name),
+			ResourceType: to.StringPtr(VirtualMachines),
+			Locations:    &[]string{"eastus"},
+		}
+		if cachedDiskBytes != "" {
+			s.Capabilities = &[]compute.ResourceSkuCapabilities{
+				{Name: to.StringPtr(CachedDiskBytes), Value: to.StringPtr(cachedDiskBytes)},
+			}
+		}
+		return s
+	}
+	t0 := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	t1 := t0.Add(24 * time.Hour)
+	t2 := t1.Add(24 * time.Hour)
+
+	cases := map[string]struct {
+		snapshots []Snapshot
+		filters   []FilterFn
+		expect    []CapabilityChange
+	}{
+		"empty history should have no changes": {},
+		"unchanged value should have no changes": {
+			snapshots: []Snapshot{
+				{Time: t0, SKUs: []SKU{sku("foo", "100")}},
+				{Time: t1, SKUs: []SKU{sku("foo", "100")}},
+			},
+		},
+		"should report changes in chronological order": {
+			snapshots: []Snapshot{
+				{Time: t2, SKUs: []SKU{sku("foo", "")}},
+				{Time: t0, SKUs: []SKU{sku("foo", "100")}},
+				{Time: t1, SKUs: []SKU{sku("foo", "200")}},
+			},
+			expect: []CapabilityChange{
+				{
+					ResourceType: VirtualMachines,
+					Name:         "foo",
+					Location:     "eastus",
+					Capability:   CachedDiskBytes,
+					Time:         t1,
+					Previous:     to.StringPtr("100"),
+					Current:      to.StringPtr("200"),
+				},
+				{
+					ResourceType: VirtualMachines,
+					Name:         "foo",
+					Location:     "eastus",
+					Capability:   CachedDiskBytes,
+					Time:         t2,
+					Previous:     to.StringPtr("200"),
+				},
+			},
+		},
+		"missing sku should compare against last observation": {
+			snapshots: []Snapshot{
+				{Time: t0, SKUs: []SKU{sku("foo", "100"), sku("bar", "100")}},
+				{Time: t1, SKUs: []SKU{sku("bar", "100")}},
+				{Time: t2, SKUs: []SKU{sku("foo", "100"), sku("bar", "100")}},
+			},
+		},
+		"should only report filtered skus": {
+			snapshots: []Snapshot{
+				{Time: t0, SKUs: []SKU{sku("foo", "100"), sku("bar", "100")}},
+				{Time: t1, SKUs: []SKU{sku("foo", "200"), sku("bar", "200")}},
+			},
+			filters: []FilterFn{NameFilter("bar")},
+			expect: []CapabilityChange{
+				{
+					ResourceType: VirtualMachines,
+					Name:         "bar",
+					Location:     "eastus",
+					Capability:   CachedDiskBytes,
+					Time:         t1,
+					Previous:     to.StringPtr("100"),
+					Current:      to.StringPtr("200"),
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			actual := CapabilityHistory(tc.snapshots, CachedDiskBytes, tc.filters...)
+			if diff := cmp.Diff(tc.expect, actual); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package skewer
+
+// armParametersSchema is the schema of ARM and Bicep parameter files.
+const armParametersSchema = "https://schema.management.azure.com/schemas/2019-04-01/deploymentParameters.json#"
+
+// Deployment is a sku and zone decision to hand over to infrastructure
+// as code tooling.
+type Deployment struct {
+	Size     string
+	Location string
+	Zones    []string
+}
+
+// NewDeployment returns the deployment of the sku in the location and
+// zones.
+func NewDeployment(sku *SKU, location string, zones ...string) Deployment {
+	return Deployment{
+		Size:     sku.GetName(),
+		Location: location,
+		Zones:    zones,
+	}
+}
+
+// ARMParameter is a single value of an ARM parameter file.
+type ARMParameter struct {
+	Value interface{} `json:"value"`
+}
+
+// ARMParameterFile is the JSON snippet of an ARM or Bicep parameter file.
+type ARMParameterFile struct {
+	Schema         string                  `json:"$schema"`
+	ContentVersion string                  `json:"contentVersion"`
+	Parameters     map[string]ARMParameter `json:"parameters"`
+}
+
+// ARMParameters renders the deployment as an ARM or Bicep parameter
+// file, with the parameters vmSize, location and zones. Zones are
+// omitted for regional deployments.
+func (d Deployment) ARMParameters() ARMParameterFile {
+	parameters := map[string]ARMParameter{
+		"vmSize":   {Value: d.Size},
+		"location": {Value: d.Location},
+	}
+	if len(d.Zones) > 0 {
+		parameters["zones"] = ARMParameter{Value: d.Zones}
+	}
+	return ARMParameterFile{
+		Schema:         armParametersSchema,
+		ContentVersion: "1.0.0.0",
+		Parameters:     parameters,
+	}
+}
+
+// TerraformVariables renders the deployment as the content of a
+// terraform .tfvars.json file, with the variables vm_size, location and
+// zones. Zones are omitted for regional deployments.
+func (d Deployment) TerraformVariables() map[string]interface{} {
+	variables := map[string]interface{}{
+		"vm_size":  d.Size,
+		"location": d.Location,
+	}
+	if len(d.Zones) > 0 {
+		variables["zones"] = d.Zones
+	}
+	return variables
+}
@@ -0,0 +1,50 @@
+package skewer
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_Deployment(t *testing.T) {
+	sku := &SKU{Name: to.StringPtr("Standard_D4s_v3")}
+
+	cases := map[string]struct {
+		deployment      Deployment
+		expectARM       string
+		expectTerraform string
+	}{
+		"zonal deployment should render zones": {
+			deployment:      NewDeployment(sku, "eastus", "1", "2"),
+			expectARM:       `{"$schema":"` + armParametersSchema + `","contentVersion":"1.0.0.0","parameters":{"location":{"value":"eastus"},"vmSize":{"value":"Standard_D4s_v3"},"zones":{"value":["1","2"]}}}`, //nolint:lll
+			expectTerraform: `{"location":"eastus","vm_size":"Standard_D4s_v3","zones":["1","2"]}`,
+		},
+		"regional deployment should omit zones": {
+			deployment:      NewDeployment(sku, "eastus"),
+			expectARM:       `{"$schema":"` + armParametersSchema + `","contentVersion":"1.0.0.0","parameters":{"location":{"value":"eastus"},"vmSize":{"value":"Standard_D4s_v3"}}}`, //nolint:lll
+			expectTerraform: `{"location":"eastus","vm_size":"Standard_D4s_v3"}`,
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			arm, err := json.Marshal(tc.deployment.ARMParameters())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.expectARM, string(arm)); diff != "" {
+				t.Error(diff)
+			}
+			terraform, err := json.Marshal(tc.deployment.TerraformVariables())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.expectTerraform, string(terraform)); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
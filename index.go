@@ -0,0 +1,115 @@
+package skewer
+
+import (
+	"strings"
+	"sync"
+)
+
+// skuIndex indexes the data of a cache, which it is built with and
+// replaced along with.
+type skuIndex struct {
+	// bitmaps are built on first use by capabilityBitmaps.
+	bitmapsOnce sync.Once
+	bitmaps     capabilityBitmaps
+	// types partitions the skus by lower case resource type.
+	types map[string][]SKU
+	// names holds the positions of the skus by lower case name.
+	names map[string][]int
+	// locations holds the positions of the skus by normalized location.
+	locations map[string][]int
+	// families holds the positions of the skus by lower case family.
+	families map[string][]int
+}
+
+func newSKUIndex(data []SKU) *skuIndex {
+	index := &skuIndex{
+		types:     make(map[string][]SKU),
+		names:     make(map[string][]int),
+		locations: make(map[string][]int),
+		families:  make(map[string][]int),
+	}
+	for i := range data {
+		resourceType := strings.ToLower(data[i].GetResourceType())
+		index.types[resourceType] = append(index.types[resourceType], data[i])
+		name := strings.ToLower(data[i].GetName())
+		index.names[name] = append(index.names[name], i)
+		if family := strings.ToLower(data[i].GetFamilyName()); family != "" {
+			index.families[family] = append(index.families[family], i)
+		}
+		if data[i].Locations == nil {
+			continue
+		}
+		for _, location := range *data[i].Locations {
+			location = normalizeLocation(location)
+			if positions := index.locations[location]; len(positions) == 0 || positions[len(positions)-1] != i {
+				index.locations[location] = append(positions, i)
+			}
+		}
+	}
+	return index
+}
+
+// capabilityBitmaps returns the capability bitmaps of data, building
+// them on first use, or nil when data is not indexed.
+func (index *skuIndex) capabilityBitmaps(data []SKU) capabilityBitmaps {
+	if index == nil {
+		return nil
+	}
+	index.bitmapsOnce.Do(func() {
+		index.bitmaps = newCapabilityBitmaps(data)
+	})
+	return index.bitmaps
+}
+
+// ofType returns the skus of data of the resource type, matched
+// case-insensitively, scanning data when it is not indexed. Like
+// Filter, it is empty rather than nil when data is not nil. Callers must
+// not modify the result.
+func (index *skuIndex) ofType(data []SKU, resourceType string) []SKU {
+	if index == nil {
+		return Filter(data, ResourceTypeFilter(resourceType))
+	}
+	if skus, ok := index.types[strings.ToLower(resourceType)]; ok || data == nil {
+		return skus
+	}
+	return []SKU{}
+}
+
+// byName returns the skus of data with the name, matched
+// case-insensitively, scanning data when it is not indexed.
+func (index *skuIndex) byName(data []SKU, name string) []SKU {
+	if index == nil {
+		return Filter(data, NameFilter(name))
+	}
+	return pick(data, index.names[strings.ToLower(name)])
+}
+
+// byLocation returns the skus of data listing the location, compared as
+// by locationEquals, scanning data when it is not indexed.
+func (index *skuIndex) byLocation(data []SKU, location string) []SKU {
+	if index == nil {
+		return Filter(data, LocationFilter(location))
+	}
+	return pick(data, index.locations[normalizeLocation(location)])
+}
+
+// byFamily returns the skus of data of the family, matched
+// case-insensitively, scanning data when it is not indexed.
+func (index *skuIndex) byFamily(data []SKU, family string) []SKU {
+	if index == nil {
+		return Filter(data, FamilyFilter(family))
+	}
+	return pick(data, index.families[strings.ToLower(family)])
+}
+
+// pick returns the skus of data at the positions, in order.
+func pick(data []SKU, positions []int) []SKU {
+	if len(positions) == 0 {
+		return nil
+	}
+	result := make([]SKU, len(positions))
+	for i, position := range positions {
+		result[i] = data[position]
+	}
+	return result
+}
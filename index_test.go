@@ -0,0 +1,162 @@
+package skewer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-03-01/compute" //nolint:staticcheck
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_Cache_Get_Index(t *testing.T) {
+	var skus []compute.ResourceSku
+	for i := 0; i < 100; i++ {
+		for _, location := range []string{"eastus", "westus"} {
+			skus = append(skus, compute.ResourceSku{
+				ResourceType: to.StringPtr(VirtualMachines),
+				Name:         to.StringPtr(fmt.Sprintf("Standard_D%d_v3", i)),
+				Locations:    &[]string{location},
+			})
+		}
+	}
+	skus = append(skus, compute.ResourceSku{
+		ResourceType: to.StringPtr(Disks),
+		Name:         to.StringPtr("Standard_D7_v3"),
+		Locations:    &[]string{"eastus"},
+	})
+	cache, err := NewStaticCache(Wrap(skus))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	sku, err := cache.Get(ctx, "standard_d7_V3", VirtualMachines, "West US")
+	if err != nil {
+		t.Fatal(err)
+	}
+	location, err := sku.GetLocation()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff("westus", location); diff != "" {
+		t.Error(diff)
+	}
+	if diff := cmp.Diff(VirtualMachines, sku.GetResourceType()); diff != "" {
+		t.Error(diff)
+	}
+
+	if _, err := cache.Get(ctx, "Standard_D100_v3", VirtualMachines, "eastus"); err == nil {
+		t.Error("expected missing sku not to be found")
+	}
+
+	duplicated, err := NewStaticCache(Wrap(append(skus, skus[0])))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var multiple *ErrMultipleSKUsMatch
+	if _, err := duplicated.Get(ctx, "Standard_D0_v3", VirtualMachines, "eastus"); !errors.As(err, &multiple) {
+		t.Errorf("expected duplicated sku to match multiple skus, got %v", err)
+	}
+}
+
+func Test_Cache_ListInLocation(t *testing.T) {
+	skus := []compute.ResourceSku{
+		{Name: to.StringPtr("a"), Locations: &[]string{"eastus"}, LocationInfo: &[]compute.ResourceSkuLocationInfo{
+			{Location: to.StringPtr("eastus"), Zones: &[]string{"1"}},
+		}},
+		{Name: to.StringPtr("b"), Locations: &[]string{"westus"}, LocationInfo: &[]compute.ResourceSkuLocationInfo{
+			{Location: to.StringPtr("westus"), Zones: &[]string{"2"}},
+		}},
+		{Name: to.StringPtr("c"), Locations: &[]string{"EastUS", "eastus"}},
+	}
+	cache, err := NewStaticCache(Wrap(skus))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	if diff := cmp.Diff([]string{"a", "c"}, skuNames(cache.ListInLocation(ctx, "East US"))); diff != "" {
+		t.Error(diff)
+	}
+	if diff := cmp.Diff([]string{"c"}, skuNames(cache.ListInLocation(ctx, "eastus", NameFilter("c")))); diff != "" {
+		t.Error(diff)
+	}
+	if got := cache.ListInLocation(ctx, "centralus"); got != nil {
+		t.Errorf("expected no skus, got %v", skuNames(got))
+	}
+
+	regional, err := NewStaticCache(Wrap(skus), WithLocation("westus"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"2"}, regional.GetAvailabilityZones(ctx)); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func Test_Cache_GetByFamily(t *testing.T) {
+	skus := []compute.ResourceSku{
+		{Name: to.StringPtr("Standard_D2s_v3"), Family: to.StringPtr("standardDSv3Family"), Locations: &[]string{"eastus"}},
+		{Name: to.StringPtr("Standard_D4s_v3"), Family: to.StringPtr("standardDSv3Family"), Locations: &[]string{"westus"}},
+		{Name: to.StringPtr("Standard_E4s_v3"), Family: to.StringPtr("standardESv3Family"), Locations: &[]string{"eastus"}},
+		{Name: to.StringPtr("Premium_LRS")},
+	}
+	cache, err := NewStaticCache(Wrap(skus))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	if diff := cmp.Diff([]string{"Standard_D2s_v3", "Standard_D4s_v3"}, skuNames(cache.GetByFamily(ctx, "standarddsv3family"))); diff != "" {
+		t.Error(diff)
+	}
+	if got := len(cache.GetByFamily(ctx, "standardDSv3Family", LocationFilter("westus"))); got != 1 {
+		t.Errorf("expected 1 sku in westus, got %d", got)
+	}
+	if got := cache.GetByFamily(ctx, ""); got != nil {
+		t.Errorf("expected skus without family not to be indexed, got %d", len(got))
+	}
+}
+
+func Test_Cache_ResourceTypePartitions(t *testing.T) {
+	skus := []compute.ResourceSku{
+		{ResourceType: to.StringPtr(VirtualMachines), Name: to.StringPtr("Standard_D2s_v3"), Capabilities: &[]compute.ResourceSkuCapabilities{
+			{Name: to.StringPtr(CapabilityPremiumIO), Value: to.StringPtr("True")},
+		}},
+		{ResourceType: to.StringPtr("VirtualMachines"), Name: to.StringPtr("Standard_D4s_v3")},
+		{ResourceType: to.StringPtr(Disks), Name: to.StringPtr("Premium_LRS")},
+	}
+	cache, err := NewStaticCache(Wrap(skus))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	vms := cache.GetVirtualMachines(ctx)
+	if len(vms) != 2 {
+		t.Fatalf("expected 2 virtual machines, got %d", len(vms))
+	}
+	vms[0].Name = to.StringPtr("mutated")
+	if got := cache.GetVirtualMachines(ctx)[0].GetName(); got != "Standard_D2s_v3" {
+		t.Errorf("expected partitions not to be modified through results, got %s", got)
+	}
+	if got := len(cache.GetDisks(ctx)); got != 1 {
+		t.Errorf("expected 1 disk, got %d", got)
+	}
+	if got := cache.GetSnapshots(ctx); got == nil || len(got) != 0 {
+		t.Errorf("expected empty snapshots, got %v", got)
+	}
+
+	if cache.index.bitmaps != nil {
+		t.Error("expected capability bitmaps to be built lazily")
+	}
+	if got := len(cache.ListSupporting(ctx, []string{CapabilityPremiumIO})); got != 1 {
+		t.Errorf("expected 1 premium io sku, got %d", got)
+	}
+	if cache.index.bitmaps == nil {
+		t.Error("expected capability bitmaps to be built on first use")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-03-01/compute" //nolint:staticcheck
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2021-07-01/features"
 )
 
 // ResourceClient is the required Azure client interface used to populate skewer's data.
@@ -17,8 +18,21 @@ type ResourceProviderClient interface {
 	List(ctx context.Context, filter, includeExtendedLocations string) (compute.ResourceSkusResultPage, error)
 }
 
+// Source provides resource sku data to a layered cache, for example a
+// live Azure client or a local snapshot.
+type Source interface {
+	List(ctx context.Context, filter, includeExtendedLocations string) ([]compute.ResourceSku, error)
+}
+
 // client defines the internal interface required by the skewer Cache.
-// TODO(ace): implement a lazy iterator with caching (and a cursor?)
+// Consumers which do not need the full listing can use an Iterator.
 type client interface {
 	List(ctx context.Context, filter, includeExtendedLocations string) ([]compute.ResourceSku, error)
 }
+
+// FeatureClient is the optional Azure client interface used to check
+// subscription feature registrations. It matches the signature of the
+// Azure features client.
+type FeatureClient interface {
+	Get(ctx context.Context, resourceProviderNamespace, featureName string) (features.Result, error)
+}
@@ -0,0 +1,75 @@
+package skewer
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// IsolationInfo describes the isolation schedule of a hardware isolated
+// vm size, which is dedicated to a single customer.
+type IsolationInfo struct {
+	// Retirement is the date after which the size is no longer
+	// isolated. It is zero when no retirement has been announced.
+	Retirement time.Time
+	// Replacement is the suggested isolated successor, if any.
+	Replacement string
+}
+
+// isolatedSizes holds isolated sizes whose names do not carry the
+// isolated ("i") additive feature, along with announced isolation
+// retirements. Retired entries are kept so their history is reported.
+// Keys are lower case sku names.
+// See https://learn.microsoft.com/en-us/azure/virtual-machines/isolation
+var isolatedSizes = map[string]IsolationInfo{
+	"standard_f72s_v2": {},
+	"standard_m128ms":  {},
+	"standard_d15_v2":  {Retirement: time.Date(2020, time.May, 15, 0, 0, 0, 0, time.UTC)},
+	"standard_ds15_v2": {Retirement: time.Date(2020, time.May, 15, 0, 0, 0, 0, time.UTC)},
+	"standard_g5":      {Retirement: time.Date(2022, time.February, 15, 0, 0, 0, 0, time.UTC)},
+	"standard_gs5":     {Retirement: time.Date(2022, time.February, 15, 0, 0, 0, 0, time.UTC)},
+	"standard_e64i_v3": {
+		Retirement:  time.Date(2022, time.February, 15, 0, 0, 0, 0, time.UTC),
+		Replacement: "Standard_E80ids_v4",
+	},
+	"standard_e64is_v3": {
+		Retirement:  time.Date(2022, time.February, 15, 0, 0, 0, 0, time.UTC),
+		Replacement: "Standard_E80is_v4",
+	},
+}
+
+// GetIsolationInfo returns the maintained isolation metadata for this
+// sku. The boolean is false when the sku is not a known isolated size.
+func (s *SKU) GetIsolationInfo() (IsolationInfo, bool) {
+	info, ok := isolatedSizes[strings.ToLower(s.GetName())]
+	if ok {
+		return info, true
+	}
+	if vmSize, err := s.GetVMSize(); err == nil && vmSize.hasAdditiveFeature('i') {
+		return IsolationInfo{}, true
+	}
+	return IsolationInfo{}, false
+}
+
+// IsIsolated returns true when the VM size is isolated to a single
+// customer's hardware and the isolation has not been retired.
+func (s *SKU) IsIsolated() bool {
+	return s.IsIsolatedAt(time.Now())
+}
+
+// IsIsolatedAt returns true when the VM size is isolated at t, before
+// the retirement of its isolation if one was announced.
+func (s *SKU) IsIsolatedAt(now time.Time) bool {
+	info, ok := s.GetIsolationInfo()
+	if !ok {
+		return false
+	}
+	return info.Retirement.IsZero() || now.Before(info.Retirement)
+}
+
+// GetIsolatedVirtualMachines returns the virtual machine skus which are
+// isolated at the current time of the cache clock.
+func (c *Cache) GetIsolatedVirtualMachines(ctx context.Context) []SKU {
+	now := c.config.now()
+	return c.ofType(ctx, VirtualMachines, func(s *SKU) bool { return s.IsIsolatedAt(now) })
+}
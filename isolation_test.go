@@ -0,0 +1,108 @@
+package skewer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-03-01/compute" //nolint:staticcheck
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_SKU_IsIsolated(t *testing.T) {
+	cases := map[string]struct {
+		sku    compute.ResourceSku
+		now    time.Time
+		expect bool
+	}{
+		"should not be isolated without isolated feature": {
+			sku: compute.ResourceSku{
+				Name: to.StringPtr("Standard_E104s_v5"),
+				Size: to.StringPtr("E104s_v5"),
+			},
+		},
+		"should be isolated with isolated feature": {
+			sku: compute.ResourceSku{
+				Name: to.StringPtr("Standard_E104is_v5"),
+				Size: to.StringPtr("E104is_v5"),
+			},
+			expect: true,
+		},
+		"should be isolated before retirement": {
+			sku: compute.ResourceSku{
+				Name: to.StringPtr("Standard_G5"),
+				Size: to.StringPtr("G5"),
+			},
+			now:    time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC),
+			expect: true,
+		},
+		"should be isolated without retirement": {
+			sku: compute.ResourceSku{
+				Name: to.StringPtr("Standard_F72s_v2"),
+				Size: to.StringPtr("F72s_v2"),
+			},
+			now:    time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC),
+			expect: true,
+		},
+		"should be isolated the day before retirement": {
+			sku: compute.ResourceSku{
+				Name: to.StringPtr("Standard_G5"),
+				Size: to.StringPtr("G5"),
+			},
+			now:    time.Date(2022, time.February, 14, 0, 0, 0, 0, time.UTC),
+			expect: true,
+		},
+		"should not be isolated on retirement": {
+			sku: compute.ResourceSku{
+				Name: to.StringPtr("Standard_G5"),
+				Size: to.StringPtr("G5"),
+			},
+			now: time.Date(2022, time.February, 15, 0, 0, 0, 0, time.UTC),
+		},
+		"should not be isolated after retirement": {
+			sku: compute.ResourceSku{
+				Name: to.StringPtr("Standard_E64is_v3"),
+				Size: to.StringPtr("E64is_v3"),
+			},
+			now: time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			sku := SKU(tc.sku)
+			if diff := cmp.Diff(tc.expect, sku.IsIsolatedAt(tc.now)); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func Test_Cache_GetIsolatedVirtualMachines(t *testing.T) {
+	now := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	clock := func(c *Config) (*Config, error) {
+		c.clock = func() time.Time { return now }
+		return c, nil
+	}
+	vm := func(name, size string) compute.ResourceSku {
+		return compute.ResourceSku{Name: to.StringPtr(name), Size: to.StringPtr(size), ResourceType: to.StringPtr(VirtualMachines)}
+	}
+	cache, err := NewStaticCache(Wrap([]compute.ResourceSku{
+		vm("Standard_D4s_v3", "D4s_v3"),
+		vm("Standard_F72s_v2", "F72s_v2"),
+		vm("Standard_G5", "G5"),
+	}), clock)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff([]string{"Standard_F72s_v2", "Standard_G5"}, skuNames(cache.GetIsolatedVirtualMachines(context.Background()))); diff != "" {
+		t.Errorf("expected and actual isolated skus before retirement mismatch: %s", diff)
+	}
+	now = time.Date(2022, time.February, 15, 0, 0, 0, 0, time.UTC)
+	if diff := cmp.Diff([]string{"Standard_F72s_v2"}, skuNames(cache.GetIsolatedVirtualMachines(context.Background()))); diff != "" {
+		t.Errorf("expected and actual isolated skus after retirement mismatch: %s", diff)
+	}
+}
@@ -0,0 +1,174 @@
+package skewer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-03-01/compute" //nolint:staticcheck
+	"github.com/pkg/errors"
+)
+
+// Cursor is a position in an Iterator, see Iterator.Cursor.
+type Cursor int
+
+// ErrCursorOutOfRange will be returned when seeking an iterator to a
+// cursor it did not produce.
+type ErrCursorOutOfRange struct {
+	Cursor   Cursor
+	Consumed int
+}
+
+func (e *ErrCursorOutOfRange) Error() string {
+	return fmt.Sprintf("cursor %d is out of range of the %d consumed skus", e.Cursor, e.Consumed)
+}
+
+// Iterator lazily lists resource skus, fetching pages from the API only
+// as skus are consumed, so consumers needing the first match do not pay
+// for a full listing. Consumed skus are cached, so the iterator can be
+// resumed from a previous cursor without fetching them again. It is not
+// safe for concurrent use.
+type Iterator struct {
+	client                   ResourceClient
+	filter                   string
+	includeExtendedLocations string
+	iter                     compute.ResourceSkusResultIterator
+	started                  bool
+	done                     bool
+	err                      error
+	consumed                 []SKU
+	position                 int
+}
+
+// ErrUnsupportedIteratorOption will be returned when creating an
+// iterator with an option which only applies to a cache.
+type ErrUnsupportedIteratorOption struct {
+	Option string
+}
+
+func (e *ErrUnsupportedIteratorOption) Error() string {
+	return fmt.Sprintf("option %s is not supported by iterators, only WithLocation and WithExtendedLocations are", e.Option)
+}
+
+// NewIterator returns an iterator over the skus listed by the client,
+// configured with the WithLocation and WithExtendedLocations options.
+// Any other option returns an ErrUnsupportedIteratorOption.
+func NewIterator(client ResourceClient, opts ...Option) (*Iterator, error) {
+	config := &Config{}
+	for _, optionFn := range opts {
+		var err error
+		if config, err = optionFn(config); err != nil {
+			return nil, err
+		}
+	}
+	if client == nil {
+		return nil, &ErrClientNil{}
+	}
+	if option := config.unsupportedIteratorOption(); option != "" {
+		return nil, &ErrUnsupportedIteratorOption{Option: option}
+	}
+	return &Iterator{
+		client:                   client,
+		filter:                   config.filter,
+		includeExtendedLocations: config.includeExtendedLocations,
+	}, nil
+}
+
+// Next advances the iterator to the next sku, fetching the next page
+// when needed. It returns false at the end of the listing or when
+// fetching failed, see Err.
+func (it *Iterator) Next(ctx context.Context) bool {
+	if it.position < len(it.consumed) {
+		it.position++
+		return true
+	}
+	if it.done || it.err != nil {
+		return false
+	}
+
+	if !it.started {
+		iter, err := it.client.ListComplete(ctx, it.filter, it.includeExtendedLocations)
+		if err != nil {
+			it.err = errors.Wrap(err, "could not list resource skus")
+			return false
+		}
+		it.iter, it.started = iter, true
+	} else if err := it.iter.NextWithContext(ctx); err != nil {
+		it.err = errors.Wrap(err, "could not iterate resource skus")
+		return false
+	}
+
+	if !it.iter.NotDone() {
+		it.done = true
+		return false
+	}
+	it.consumed = append(it.consumed, SKU(it.iter.Value()))
+	it.position++
+	return true
+}
+
+// SKU returns the current sku. It panics unless the last call to Next
+// returned true.
+func (it *Iterator) SKU() SKU {
+	return it.consumed[it.position-1]
+}
+
+// Err returns the error which stopped the iteration, if any.
+func (it *Iterator) Err() error {
+	return it.err
+}
+
+// Cursor returns the position of the iterator: after seeking to it, Next
+// returns the sku following the current one.
+func (it *Iterator) Cursor() Cursor {
+	return Cursor(it.position)
+}
+
+// Seek moves the iterator to a cursor previously returned by Cursor,
+// replaying consumed skus from the cache.
+func (it *Iterator) Seek(cursor Cursor) error {
+	if cursor < 0 || int(cursor) > len(it.consumed) {
+		return &ErrCursorOutOfRange{Cursor: cursor, Consumed: len(it.consumed)}
+	}
+	it.position = int(cursor)
+	return nil
+}
+
+// Find advances the iterator to the next sku matching all filters,
+// returning false when none does or fetching failed, see Err.
+func (it *Iterator) Find(ctx context.Context, filters ...FilterFn) (SKU, bool) {
+	for it.Next(ctx) {
+		sku := it.SKU()
+		if All(&sku, filters) {
+			return sku, true
+		}
+	}
+	return SKU{}, false
+}
+
+// unsupportedIteratorOption returns the name of the first option set on
+// the config which an iterator cannot honor, or an empty string.
+func (c *Config) unsupportedIteratorOption() string {
+	switch {
+	case len(c.locations) > 0:
+		return "WithLocations"
+	case c.client != nil:
+		return "WithClient"
+	case c.includeRestricted:
+		return "WithIncludeRestricted"
+	case c.excludePromo:
+		return "WithoutPromo"
+	case c.ttl > 0:
+		return "WithTTL"
+	case c.queryTTL > 0:
+		return "WithQueryCache"
+	case c.diagnostics != nil:
+		return "WithDiagnostics"
+	case c.featureClient != nil:
+		return "WithFeatureClient"
+	case len(c.featureRequirements) > 0:
+		return "WithFeatureRequirements"
+	case len(c.overlay) > 0:
+		return "WithOverlay"
+	}
+	return ""
+}
@@ -0,0 +1,175 @@
+package skewer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-03-01/compute" //nolint:staticcheck
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/google/go-cmp/cmp"
+)
+
+// newPagedIterator returns an iterator over the sku lists, one page per
+// list, and the page list to observe how many pages were fetched.
+func newPagedIterator(t *testing.T, skuLists [][]compute.ResourceSku) (*Iterator, *pageList) {
+	t.Helper()
+	pages := newPageList(skuLists)
+	page := compute.NewResourceSkusResultPage(compute.ResourceSkusResult{}, pages.next)
+	if err := page.NextWithContext(context.Background()); err != nil {
+		t.Fatalf("could not fetch first page: %s", err)
+	}
+	client := &fakeResourceClient{res: compute.NewResourceSkusResultIterator(page)}
+	iter, err := NewIterator(client)
+	if err != nil {
+		t.Fatalf("could not create iterator: %s", err)
+	}
+	return iter, pages
+}
+
+func namedSkus(names ...string) []compute.ResourceSku {
+	skus := make([]compute.ResourceSku, 0, len(names))
+	for _, name := range names {
+		skus = append(skus, compute.ResourceSku{Name: to.StringPtr(name)})
+	}
+	return skus
+}
+
+func consume(ctx context.Context, iter *Iterator, count int) []string {
+	names := []string{}
+	for i := 0; i < count && iter.Next(ctx); i++ {
+		sku := iter.SKU()
+		names = append(names, sku.GetName())
+	}
+	return names
+}
+
+func Test_Iterator_Lazy(t *testing.T) {
+	ctx := context.Background()
+	iter, pages := newPagedIterator(t, [][]compute.ResourceSku{
+		namedSkus("a", "b"),
+		namedSkus("c", "d"),
+		namedSkus("e"),
+	})
+
+	if diff := cmp.Diff([]string{"a", "b"}, consume(ctx, iter, 2)); diff != "" {
+		t.Errorf("expected and actual skus mismatch: %s", diff)
+	}
+	if pages.cursor != 1 {
+		t.Errorf("expected 1 page fetched after consuming the first page, got %d", pages.cursor)
+	}
+
+	sku, ok := iter.Find(ctx, func(s *SKU) bool { return s.GetName() == "c" })
+	if !ok || sku.GetName() != "c" {
+		t.Fatalf("expected to find sku c, got %q, %t", sku.GetName(), ok)
+	}
+	if pages.cursor != 2 {
+		t.Errorf("expected 2 pages fetched after finding c, got %d", pages.cursor)
+	}
+
+	if diff := cmp.Diff([]string{"d", "e"}, consume(ctx, iter, 2)); diff != "" {
+		t.Errorf("expected and actual skus mismatch: %s", diff)
+	}
+	if iter.Next(ctx) {
+		t.Errorf("expected iterator to be exhausted")
+	}
+	if err := iter.Err(); err != nil {
+		t.Errorf("expected no error, got %s", err)
+	}
+}
+
+func Test_Iterator_Seek(t *testing.T) {
+	ctx := context.Background()
+	iter, pages := newPagedIterator(t, [][]compute.ResourceSku{
+		namedSkus("a", "b"),
+		namedSkus("c", "d"),
+	})
+
+	consume(ctx, iter, 1)
+	cursor := iter.Cursor()
+	if diff := cmp.Diff([]string{"b", "c", "d"}, consume(ctx, iter, 4)); diff != "" {
+		t.Errorf("expected and actual skus mismatch: %s", diff)
+	}
+	fetched := pages.cursor
+
+	if err := iter.Seek(cursor); err != nil {
+		t.Fatalf("expected no error seeking, got %s", err)
+	}
+	if diff := cmp.Diff([]string{"b", "c", "d"}, consume(ctx, iter, 4)); diff != "" {
+		t.Errorf("expected and actual skus mismatch after seeking: %s", diff)
+	}
+	if pages.cursor != fetched {
+		t.Errorf("expected replay from cache, fetched %d more pages", pages.cursor-fetched)
+	}
+
+	var rangeErr *ErrCursorOutOfRange
+	if err := iter.Seek(Cursor(5)); !errors.As(err, &rangeErr) {
+		t.Errorf("expected ErrCursorOutOfRange, got %v", err)
+	}
+}
+
+func Test_Iterator_Errors(t *testing.T) {
+	ctx := context.Background()
+
+	if _, err := NewIterator(nil); !errors.As(err, new(*ErrClientNil)) {
+		t.Errorf("expected ErrClientNil, got %v", err)
+	}
+
+	client := newFailingFakeResourceClient(errors.New("list failed"))
+	for name, option := range map[string]Option{
+		"WithLocations":         WithLocations("eastus", "westus2"),
+		"WithoutPromo":          WithoutPromo(),
+		"WithIncludeRestricted": WithIncludeRestricted(),
+		"WithTTL":               WithTTL(time.Hour),
+	} {
+		var unsupported *ErrUnsupportedIteratorOption
+		if _, err := NewIterator(client, WithLocation("eastus"), option); !errors.As(err, &unsupported) || unsupported.Option != name {
+			t.Errorf("expected ErrUnsupportedIteratorOption for %s, got %v", name, err)
+		}
+	}
+	if _, err := NewIterator(client, WithLocation("eastus"), WithExtendedLocations()); err != nil {
+		t.Errorf("expected supported options to be accepted, got %s", err)
+	}
+
+	iter, err := NewIterator(client)
+	if err != nil {
+		t.Fatalf("could not create iterator: %s", err)
+	}
+	if iter.Next(ctx) {
+		t.Errorf("expected no skus from failing client")
+	}
+	if iter.Err() == nil {
+		t.Errorf("expected listing error")
+	}
+
+	failing := false
+	pages := newPageList([][]compute.ResourceSku{namedSkus("a"), namedSkus("b")})
+	next := func(ctx context.Context, last compute.ResourceSkusResult) (compute.ResourceSkusResult, error) {
+		if failing {
+			return compute.ResourceSkusResult{}, errors.New("page failed")
+		}
+		return pages.next(ctx, last)
+	}
+	page := compute.NewResourceSkusResultPage(compute.ResourceSkusResult{}, next)
+	if err := page.NextWithContext(ctx); err != nil {
+		t.Fatalf("could not fetch first page: %s", err)
+	}
+	iter, err = NewIterator(&fakeResourceClient{res: compute.NewResourceSkusResultIterator(page)})
+	if err != nil {
+		t.Fatalf("could not create iterator: %s", err)
+	}
+	failing = true
+	if diff := cmp.Diff([]string{"a"}, consume(ctx, iter, 2)); diff != "" {
+		t.Errorf("expected and actual skus mismatch: %s", diff)
+	}
+	if iter.Err() == nil {
+		t.Errorf("expected paging error")
+	}
+	if err := iter.Seek(0); err != nil {
+		t.Fatalf("expected no error seeking, got %s", err)
+	}
+	if diff := cmp.Diff([]string{"a"}, consume(ctx, iter, 2)); diff != "" {
+		t.Errorf("expected consumed skus to be replayed after an error: %s", diff)
+	}
+}
@@ -0,0 +1,266 @@
+package skewer
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-03-01/compute" //nolint:staticcheck
+)
+
+// skuJSON is the flattened, pointer free json schema of a SKU.
+// Capabilities are a map, so their API order and any duplicate names
+// are not preserved.
+type skuJSON struct {
+	ResourceType string             `json:"resourceType,omitempty"`
+	Name         string             `json:"name,omitempty"`
+	Tier         string             `json:"tier,omitempty"`
+	Size         string             `json:"size,omitempty"`
+	Family       string             `json:"family,omitempty"`
+	Kind         string             `json:"kind,omitempty"`
+	Capacity     *capacityJSON      `json:"capacity,omitempty"`
+	Locations    []string           `json:"locations,omitempty"`
+	LocationInfo []locationInfoJSON `json:"locationInfo,omitempty"`
+	APIVersions  []string           `json:"apiVersions,omitempty"`
+	Costs        []costJSON         `json:"costs,omitempty"`
+	Capabilities map[string]string  `json:"capabilities,omitempty"`
+	Restrictions []restrictionJSON  `json:"restrictions,omitempty"`
+}
+
+type capacityJSON struct {
+	Minimum   *int64                               `json:"minimum,omitempty"`
+	Maximum   *int64                               `json:"maximum,omitempty"`
+	Default   *int64                               `json:"default,omitempty"`
+	ScaleType compute.ResourceSkuCapacityScaleType `json:"scaleType,omitempty"`
+}
+
+type costJSON struct {
+	MeterID      string `json:"meterId,omitempty"`
+	Quantity     *int64 `json:"quantity,omitempty"`
+	ExtendedUnit string `json:"extendedUnit,omitempty"`
+}
+
+type locationInfoJSON struct {
+	Location          string                       `json:"location"`
+	Zones             []string                     `json:"zones,omitempty"`
+	ZoneDetails       []zoneDetailsJSON            `json:"zoneDetails,omitempty"`
+	ExtendedLocations []string                     `json:"extendedLocations,omitempty"`
+	Type              compute.ExtendedLocationType `json:"type,omitempty"`
+}
+
+type zoneDetailsJSON struct {
+	Zones        []string          `json:"zones,omitempty"`
+	Capabilities map[string]string `json:"capabilities,omitempty"`
+}
+
+type restrictionJSON struct {
+	Type      compute.ResourceSkuRestrictionsType       `json:"type,omitempty"`
+	Reason    compute.ResourceSkuRestrictionsReasonCode `json:"reason,omitempty"`
+	Values    []string                                  `json:"values,omitempty"`
+	Locations []string                                  `json:"locations,omitempty"`
+	Zones     []string                                  `json:"zones,omitempty"`
+}
+
+// MarshalJSON encodes the sku in a flattened, pointer free schema with
+// capabilities as a map of names to values. Unlike the sdk models, it
+// keeps every field the API returns. It has a value receiver so that
+// skus encode the same whether or not they are addressable.
+func (s SKU) MarshalJSON() ([]byte, error) { //nolint:gocritic
+	flat := skuJSON{
+		ResourceType: s.GetResourceType(),
+		Name:         s.GetName(),
+		Tier:         s.GetTier(),
+		Size:         s.GetSize(),
+		Family:       s.GetFamilyName(),
+		Kind:         stringValue(s.Kind),
+		Locations:    stringsValue(s.Locations),
+		APIVersions:  stringsValue(s.APIVersions),
+		Capabilities: capabilityMap(s.Capabilities),
+	}
+	if s.Capacity != nil {
+		flat.Capacity = &capacityJSON{
+			Minimum:   s.Capacity.Minimum,
+			Maximum:   s.Capacity.Maximum,
+			Default:   s.Capacity.Default,
+			ScaleType: s.Capacity.ScaleType,
+		}
+	}
+	if s.Costs != nil {
+		for _, cost := range *s.Costs {
+			flat.Costs = append(flat.Costs, costJSON{
+				MeterID:      stringValue(cost.MeterID),
+				Quantity:     cost.Quantity,
+				ExtendedUnit: stringValue(cost.ExtendedUnit),
+			})
+		}
+	}
+	if s.LocationInfo != nil {
+		for _, info := range *s.LocationInfo {
+			flatInfo := locationInfoJSON{
+				Location:          stringValue(info.Location),
+				Zones:             stringsValue(info.Zones),
+				ExtendedLocations: stringsValue(info.ExtendedLocations),
+				Type:              info.Type,
+			}
+			if info.ZoneDetails != nil {
+				for _, details := range *info.ZoneDetails {
+					flatInfo.ZoneDetails = append(flatInfo.ZoneDetails, zoneDetailsJSON{
+						Zones:        stringsValue(details.Name),
+						Capabilities: capabilityMap(details.Capabilities),
+					})
+				}
+			}
+			flat.LocationInfo = append(flat.LocationInfo, flatInfo)
+		}
+	}
+	if s.Restrictions != nil {
+		for _, restriction := range *s.Restrictions {
+			flatRestriction := restrictionJSON{
+				Type:   restriction.Type,
+				Reason: restriction.ReasonCode,
+				Values: stringsValue(restriction.Values),
+			}
+			if restriction.RestrictionInfo != nil {
+				flatRestriction.Locations = stringsValue(restriction.RestrictionInfo.Locations)
+				flatRestriction.Zones = stringsValue(restriction.RestrictionInfo.Zones)
+			}
+			flat.Restrictions = append(flat.Restrictions, flatRestriction)
+		}
+	}
+	return json.Marshal(flat)
+}
+
+// UnmarshalJSON decodes the schema written by MarshalJSON. Capabilities
+// are restored in name order, and empty strings and lists as nil.
+func (s *SKU) UnmarshalJSON(data []byte) error {
+	var flat skuJSON
+	if err := json.Unmarshal(data, &flat); err != nil {
+		return err
+	}
+
+	sku := SKU{
+		ResourceType: stringPtr(flat.ResourceType),
+		Name:         stringPtr(flat.Name),
+		Tier:         stringPtr(flat.Tier),
+		Size:         stringPtr(flat.Size),
+		Family:       stringPtr(flat.Family),
+		Kind:         stringPtr(flat.Kind),
+		Locations:    stringsPtr(flat.Locations),
+		APIVersions:  stringsPtr(flat.APIVersions),
+		Capabilities: capabilityList(flat.Capabilities),
+	}
+	if flat.Capacity != nil {
+		sku.Capacity = &compute.ResourceSkuCapacity{
+			Minimum:   flat.Capacity.Minimum,
+			Maximum:   flat.Capacity.Maximum,
+			Default:   flat.Capacity.Default,
+			ScaleType: flat.Capacity.ScaleType,
+		}
+	}
+	if flat.Costs != nil {
+		costs := make([]compute.ResourceSkuCosts, 0, len(flat.Costs))
+		for _, cost := range flat.Costs {
+			costs = append(costs, compute.ResourceSkuCosts{
+				MeterID:      stringPtr(cost.MeterID),
+				Quantity:     cost.Quantity,
+				ExtendedUnit: stringPtr(cost.ExtendedUnit),
+			})
+		}
+		sku.Costs = &costs
+	}
+	if flat.LocationInfo != nil {
+		infos := make([]compute.ResourceSkuLocationInfo, 0, len(flat.LocationInfo))
+		for _, flatInfo := range flat.LocationInfo {
+			info := compute.ResourceSkuLocationInfo{
+				Location:          stringPtr(flatInfo.Location),
+				Zones:             stringsPtr(flatInfo.Zones),
+				ExtendedLocations: stringsPtr(flatInfo.ExtendedLocations),
+				Type:              flatInfo.Type,
+			}
+			if flatInfo.ZoneDetails != nil {
+				details := make([]compute.ResourceSkuZoneDetails, 0, len(flatInfo.ZoneDetails))
+				for _, flatDetails := range flatInfo.ZoneDetails {
+					details = append(details, compute.ResourceSkuZoneDetails{
+						Name:         stringsPtr(flatDetails.Zones),
+						Capabilities: capabilityList(flatDetails.Capabilities),
+					})
+				}
+				info.ZoneDetails = &details
+			}
+			infos = append(infos, info)
+		}
+		sku.LocationInfo = &infos
+	}
+	if flat.Restrictions != nil {
+		restrictions := make([]compute.ResourceSkuRestrictions, 0, len(flat.Restrictions))
+		for _, flatRestriction := range flat.Restrictions {
+			restriction := compute.ResourceSkuRestrictions{
+				Type:       flatRestriction.Type,
+				ReasonCode: flatRestriction.Reason,
+				Values:     stringsPtr(flatRestriction.Values),
+			}
+			if flatRestriction.Locations != nil || flatRestriction.Zones != nil {
+				restriction.RestrictionInfo = &compute.ResourceSkuRestrictionInfo{
+					Locations: stringsPtr(flatRestriction.Locations),
+					Zones:     stringsPtr(flatRestriction.Zones),
+				}
+			}
+			restrictions = append(restrictions, restriction)
+		}
+		sku.Restrictions = &restrictions
+	}
+
+	*s = sku
+	return nil
+}
+
+// capabilityMap flattens capabilities, keeping the first of duplicate
+// names like CapabilityMap.
+func capabilityMap(capabilities *[]compute.ResourceSkuCapabilities) map[string]string {
+	return (&SKU{Capabilities: capabilities}).CapabilityMap()
+}
+
+// capabilityList restores capabilities from a map in name order.
+func capabilityList(capabilities map[string]string) *[]compute.ResourceSkuCapabilities {
+	if capabilities == nil {
+		return nil
+	}
+	names := make([]string, 0, len(capabilities))
+	for name := range capabilities {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	result := make([]compute.ResourceSkuCapabilities, 0, len(names))
+	for _, name := range names {
+		name, value := name, capabilities[name]
+		result = append(result, compute.ResourceSkuCapabilities{Name: &name, Value: &value})
+	}
+	return &result
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+func stringPtr(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+func stringsValue(values *[]string) []string {
+	if values == nil {
+		return nil
+	}
+	return *values
+}
+
+func stringsPtr(values []string) *[]string {
+	if values == nil {
+		return nil
+	}
+	return &values
+}
@@ -0,0 +1,58 @@
+package skewer
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-03-01/compute" //nolint:staticcheck
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func Test_SKU_JSON(t *testing.T) {
+	dataWrapper, err := newDataWrapper("./testdata/eastus.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	skus := Wrap(dataWrapper.Value)
+
+	data, err := json.Marshal(skus)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded []SKU
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	sortCapabilities := cmpopts.SortSlices(func(a, b compute.ResourceSkuCapabilities) bool {
+		return *a.Name < *b.Name
+	})
+	if diff := cmp.Diff(skus, decoded, sortCapabilities, equateEmptyLists); diff != "" {
+		t.Error(diff)
+	}
+
+	again, err := json.Marshal(decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(string(data), string(again)); diff != "" {
+		t.Error(diff)
+	}
+
+	single, err := json.Marshal(skus[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(data[1:len(single)+1], single); diff != "" {
+		t.Errorf("expected sku values to use the flattened schema\n%s", diff)
+	}
+
+	var flat []map[string]interface{}
+	if err := json.Unmarshal(data, &flat); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff("2", flat[0]["capabilities"].(map[string]interface{})[VCPUs]); diff != "" {
+		t.Errorf("expected capabilities to be a map of names to values\n%s", diff)
+	}
+}
@@ -0,0 +1,82 @@
+package skewer
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-03-01/compute" //nolint:staticcheck
+)
+
+// staticSource serves fixed data, such as a local snapshot.
+type staticSource struct {
+	data []compute.ResourceSku
+}
+
+// StaticSource returns a Source serving fixed data, such as a local
+// snapshot for fast cold starts.
+func StaticSource(data []compute.ResourceSku) Source {
+	return &staticSource{data}
+}
+
+func (s *staticSource) List(ctx context.Context, filter, includeExtendedLocations string) ([]compute.ResourceSku, error) {
+	return s.data, nil
+}
+
+// ResourceClientSource returns a Source backed by a ResourceClient.
+func ResourceClientSource(client ResourceClient) Source {
+	return newWrappedResourceClient(client)
+}
+
+// NewLayeredCache instantiates a cache from sources listed in increasing
+// order of precedence, e.g. a local snapshot followed by a live client.
+// Construction loads the first source which lists successfully, for a
+// fast cold start. Refresh then replaces the data with the highest
+// precedence source which lists successfully, for eventual freshness.
+func NewLayeredCache(ctx context.Context, sources []Source, opts ...Option) (*Cache, error) {
+	if len(sources) == 0 {
+		return nil, &ErrClientNil{}
+	}
+
+	config := &Config{}
+
+	for _, optionFn := range opts {
+		var err error
+		if config, err = optionFn(config); err != nil {
+			return nil, err
+		}
+	}
+
+	config.sources = sources
+
+	c := &Cache{
+		config: config,
+	}
+
+	var lastErr error
+	for _, source := range sources {
+		data, err := config.list(ctx, source)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		c.load(data)
+		return c, nil
+	}
+
+	return nil, lastErr
+}
+
+// refreshLayers loads the highest precedence source which lists
+// successfully, returning the last error when all of them fail.
+func (c *Cache) refreshLayers(ctx context.Context) error {
+	var lastErr error
+	for i := len(c.config.sources) - 1; i >= 0; i-- {
+		data, err := c.config.list(ctx, c.config.sources[i])
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		c.load(data)
+		return nil
+	}
+	return lastErr
+}
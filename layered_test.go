@@ -0,0 +1,60 @@
+package skewer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-03-01/compute" //nolint:staticcheck
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_NewLayeredCache(t *testing.T) {
+	ctx := context.Background()
+	snapshot := StaticSource([]compute.ResourceSku{{Name: to.StringPtr("snapshot")}})
+	live := &fakeClient{skus: []compute.ResourceSku{{Name: to.StringPtr("live")}}}
+	failing := &fakeClient{err: errors.New("throttled")}
+
+	t.Run("should require a source", func(t *testing.T) {
+		var errClientNil *ErrClientNil
+		if _, err := NewLayeredCache(ctx, nil); !errors.As(err, &errClientNil) {
+			t.Errorf("expected client nil error, got '%v'", err)
+		}
+	})
+
+	t.Run("should start from first source and refresh from last", func(t *testing.T) {
+		cache, err := NewLayeredCache(ctx, []Source{snapshot, live})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]string{"snapshot"}, skuNames(cache.List(ctx))); diff != "" {
+			t.Error(diff)
+		}
+		if err := cache.Refresh(ctx); err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]string{"live"}, skuNames(cache.List(ctx))); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("should fall back when sources fail", func(t *testing.T) {
+		cache, err := NewLayeredCache(ctx, []Source{failing, snapshot, failing})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := cache.Refresh(ctx); err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]string{"snapshot"}, skuNames(cache.List(ctx))); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("should error when all sources fail", func(t *testing.T) {
+		if _, err := NewLayeredCache(ctx, []Source{failing}); err == nil {
+			t.Error("expected error")
+		}
+	})
+}
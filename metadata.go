@@ -0,0 +1,92 @@
+package skewer
+
+import (
+	"strings"
+	"sync"
+)
+
+// NameMetadataProvider supplies knowledge derived from VM size names,
+// which the resource sku API does not publish. Series are identified by
+// family, sub-family and version, e.g. "HB_v3" for "HB120rs_v3", or
+// "NC" for "NC6". Accelerator types are upper case, e.g. "T4".
+type NameMetadataProvider interface {
+	// GPU returns the GPU of a size by its accelerator type, which is
+	// empty for sizes without one, and its series.
+	GPU(acceleratorType, series string) (GPUInfo, bool)
+	// InfiniBand returns the InfiniBand interconnect of a series.
+	InfiniBand(series string) (InfiniBandInfo, bool)
+}
+
+// NameMetadataTables is a NameMetadataProvider backed by static tables,
+// for example to teach skewer about a series before it ships built-in
+// knowledge.
+type NameMetadataTables struct {
+	GPUAccelerators  map[string]GPUInfo
+	GPUSeries        map[string]GPUInfo
+	InfiniBandSeries map[string]InfiniBandInfo
+}
+
+// GPU implements NameMetadataProvider, preferring the accelerator type.
+func (t NameMetadataTables) GPU(acceleratorType, series string) (GPUInfo, bool) {
+	if acceleratorType != "" {
+		if info, ok := t.GPUAccelerators[strings.ToUpper(acceleratorType)]; ok {
+			return info, true
+		}
+	}
+	info, ok := t.GPUSeries[series]
+	return info, ok
+}
+
+// InfiniBand implements NameMetadataProvider.
+func (t NameMetadataTables) InfiniBand(series string) (InfiniBandInfo, bool) {
+	info, ok := t.InfiniBandSeries[series]
+	return info, ok
+}
+
+// builtinNameMetadata holds the knowledge shipped with skewer.
+var builtinNameMetadata = NameMetadataTables{
+	GPUAccelerators:  gpuAccelerators,
+	GPUSeries:        gpuSeries,
+	InfiniBandSeries: infiniBandSeries,
+}
+
+var (
+	nameMetadataMu       sync.RWMutex
+	nameMetadataOverride NameMetadataProvider
+)
+
+// SetNameMetadataProvider installs a provider consulted before the
+// built-in knowledge, for every sku. A nil provider restores the
+// built-in knowledge only.
+func SetNameMetadataProvider(provider NameMetadataProvider) {
+	nameMetadataMu.Lock()
+	defer nameMetadataMu.Unlock()
+	nameMetadataOverride = provider
+}
+
+// lookupGPU consults the installed provider, then the built-in tables.
+func lookupGPU(acceleratorType, series string) (GPUInfo, bool) {
+	nameMetadataMu.RLock()
+	override := nameMetadataOverride
+	nameMetadataMu.RUnlock()
+	if override != nil {
+		if info, ok := override.GPU(acceleratorType, series); ok {
+			return info, true
+		}
+	}
+	return builtinNameMetadata.GPU(acceleratorType, series)
+}
+
+// lookupInfiniBand consults the installed provider, then the built-in
+// tables.
+func lookupInfiniBand(series string) (InfiniBandInfo, bool) {
+	nameMetadataMu.RLock()
+	override := nameMetadataOverride
+	nameMetadataMu.RUnlock()
+	if override != nil {
+		if info, ok := override.InfiniBand(series); ok {
+			return info, true
+		}
+	}
+	return builtinNameMetadata.InfiniBand(series)
+}
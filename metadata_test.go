@@ -0,0 +1,73 @@
+package skewer
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-03-01/compute" //nolint:staticcheck
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_SetNameMetadataProvider(t *testing.T) {
+	SetNameMetadataProvider(NameMetadataTables{
+		GPUAccelerators:  map[string]GPUInfo{"B200": {GPUVendorNVIDIA, "B200"}},
+		GPUSeries:        map[string]GPUInfo{"NV_v3": {GPUVendorNVIDIA, "Override"}},
+		InfiniBandSeries: map[string]InfiniBandInfo{"ND_v6": {Generation: "XDR", Gbps: 800, Ports: 8}},
+	})
+	defer SetNameMetadataProvider(nil)
+
+	rdma := &[]compute.ResourceSkuCapabilities{
+		{Name: to.StringPtr(CapabilityRdmaEnabled), Value: to.StringPtr("True")},
+	}
+
+	cases := map[string]struct {
+		sku        SKU
+		gpu        GPUInfo
+		gpuFound   bool
+		infiniBand InfiniBandInfo
+		ibFound    bool
+	}{
+		"should find gpu for new accelerator": {
+			sku:      SKU{Size: to.StringPtr("ND96isr_B200_v6")},
+			gpu:      GPUInfo{GPUVendorNVIDIA, "B200"},
+			gpuFound: true,
+		},
+		"should prefer override over builtin series": {
+			sku:      SKU{Size: to.StringPtr("NV12s_v3")},
+			gpu:      GPUInfo{GPUVendorNVIDIA, "Override"},
+			gpuFound: true,
+		},
+		"should fall back to builtin knowledge": {
+			sku:      SKU{Size: to.StringPtr("NC4as_T4_v3")},
+			gpu:      GPUInfo{GPUVendorNVIDIA, "Tesla T4"},
+			gpuFound: true,
+		},
+		"should find infiniband for new series": {
+			sku:        SKU{Size: to.StringPtr("ND96isr_B200_v6"), Capabilities: rdma},
+			gpu:        GPUInfo{GPUVendorNVIDIA, "B200"},
+			gpuFound:   true,
+			infiniBand: InfiniBandInfo{Generation: "XDR", Gbps: 800, Ports: 8},
+			ibFound:    true,
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			gpu, ok := tc.sku.GetGPUInfo()
+			if ok != tc.gpuFound {
+				t.Errorf("expected gpu found %t, got %t", tc.gpuFound, ok)
+			}
+			if diff := cmp.Diff(tc.gpu, gpu); diff != "" {
+				t.Error(diff)
+			}
+			infiniBand, ok := tc.sku.GetInfiniBandInfo()
+			if ok != tc.ibFound {
+				t.Errorf("expected infiniband found %t, got %t", tc.ibFound, ok)
+			}
+			if diff := cmp.Diff(tc.infiniBand, infiniBand); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package skewer
+
+// NetworkCapabilities summarizes the networking related capabilities of
+// a VM size.
+type NetworkCapabilities struct {
+	AcceleratedNetworking bool
+	DualStack             bool
+	RDMA                  bool
+	MaxNetworkInterfaces  int64
+}
+
+// IsDualStackSupported returns true for VM sizes, which are assumed to
+// support IPv4/IPv6 dual stack networking. The resource sku API
+// publishes no capability for it, so nothing about the sku is checked
+// beyond its resource type.
+func (s *SKU) IsDualStackSupported() bool {
+	return s.IsResourceType(VirtualMachines)
+}
+
+// GetNetworkCapabilities returns the networking capabilities of the VM
+// size. Missing quantities are reported as zero.
+func (s *SKU) GetNetworkCapabilities() NetworkCapabilities {
+	result := NetworkCapabilities{
+		AcceleratedNetworking: s.IsAcceleratedNetworkingSupported(),
+		DualStack:             s.IsDualStackSupported(),
+		RDMA:                  s.IsRdmaEnabled(),
+	}
+	if count, err := s.MaxNICs(); err == nil {
+		result.MaxNetworkInterfaces = count
+	}
+	return result
+}
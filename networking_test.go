@@ -0,0 +1,61 @@
+package skewer
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-03-01/compute" //nolint:staticcheck
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_SKU_GetNetworkCapabilities(t *testing.T) {
+	cases := map[string]struct {
+		sku    compute.ResourceSku
+		expect NetworkCapabilities
+	}{
+		"should report nothing for empty sku": {
+			sku: compute.ResourceSku{},
+		},
+		"should not report dual stack for non virtual machines": {
+			sku: compute.ResourceSku{
+				ResourceType: to.StringPtr(Disks),
+				Capabilities: &[]compute.ResourceSkuCapabilities{
+					{Name: to.StringPtr(CapabilityMaxNetworkInterfaces), Value: to.StringPtr("2")},
+				},
+			},
+			expect: NetworkCapabilities{MaxNetworkInterfaces: 2},
+		},
+		"should assume dual stack for virtual machines": {
+			sku: compute.ResourceSku{
+				ResourceType: to.StringPtr(VirtualMachines),
+			},
+			expect: NetworkCapabilities{DualStack: true},
+		},
+		"should report all networking capabilities": {
+			sku: compute.ResourceSku{
+				ResourceType: to.StringPtr(VirtualMachines),
+				Capabilities: &[]compute.ResourceSkuCapabilities{
+					{Name: to.StringPtr(AcceleratedNetworking), Value: to.StringPtr("True")},
+					{Name: to.StringPtr(CapabilityRdmaEnabled), Value: to.StringPtr("True")},
+					{Name: to.StringPtr(CapabilityMaxNetworkInterfaces), Value: to.StringPtr("8")},
+				},
+			},
+			expect: NetworkCapabilities{
+				AcceleratedNetworking: true,
+				DualStack:             true,
+				RDMA:                  true,
+				MaxNetworkInterfaces:  8,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			sku := SKU(tc.sku)
+			if diff := cmp.Diff(tc.expect, sku.GetNetworkCapabilities()); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package skewer
+
+import (
+	"sort"
+	"strings"
+)
+
+// Skus returned by the cache, and query results built from them, are in
+// a stable canonical order: by case-insensitive name, then location,
+// then resource type. Zones are returned in numeric order. Results
+// therefore only change between refreshes when the data does.
+
+// sortKey identifies a sku in the canonical order. Comparing keys as
+// strings compares their components in turn, since "/" sorts before any
+// character of names, locations and resource types.
+func sortKey(s *SKU) string {
+	locations := ""
+	if s.Locations != nil {
+		locations = normalizeLocation(strings.Join(*s.Locations, ","))
+	}
+	return strings.ToLower(s.GetName()) + "/" + locations + "/" + strings.ToLower(s.GetResourceType())
+}
+
+// sortSKUs sorts skus in the canonical order, in place.
+func sortSKUs(skus []SKU) {
+	keys := make([]string, len(skus))
+	for i := range skus {
+		keys[i] = sortKey(&skus[i])
+	}
+	sort.Stable(bySortKey{skus, keys})
+}
+
+// bySortKey sorts skus along with their sort keys.
+type bySortKey struct {
+	skus []SKU
+	keys []string
+}
+
+func (b bySortKey) Len() int           { return len(b.skus) }
+func (b bySortKey) Less(i, j int) bool { return b.keys[i] < b.keys[j] }
+func (b bySortKey) Swap(i, j int) {
+	b.skus[i], b.skus[j] = b.skus[j], b.skus[i]
+	b.keys[i], b.keys[j] = b.keys[j], b.keys[i]
+}
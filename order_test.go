@@ -0,0 +1,54 @@
+package skewer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-03-01/compute" //nolint:staticcheck
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_CanonicalOrder(t *testing.T) {
+	sku := func(name, location, resourceType string) compute.ResourceSku {
+		return compute.ResourceSku{
+			Name:         to.StringPtr(name),
+			Locations:    &[]string{location},
+			ResourceType: to.StringPtr(resourceType),
+			LocationInfo: &[]compute.ResourceSkuLocationInfo{
+				{Location: to.StringPtr(location), Zones: &[]string{"10", "2", "1"}},
+			},
+		}
+	}
+	data := Wrap([]compute.ResourceSku{
+		sku("b", "eastus", VirtualMachines),
+		sku("B", "centralus", VirtualMachines),
+		sku("a", "westus", Disks),
+		sku("a", "westus", VirtualMachines),
+	})
+
+	cache, err := NewStaticCache(data, WithLocation("westus"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var keys []string
+	for _, s := range cache.List(context.Background()) {
+		keys = append(keys, s.GetName()+" "+(*s.Locations)[0]+" "+s.GetResourceType())
+	}
+	expect := []string{
+		"a westus disks",
+		"a westus virtualMachines",
+		"B centralus virtualMachines",
+		"b eastus virtualMachines",
+	}
+	if diff := cmp.Diff(expect, keys); diff != "" {
+		t.Error(diff)
+	}
+	if diff := cmp.Diff("b", data[0].GetName()); diff != "" {
+		t.Errorf("expected input data not to be reordered: %s", diff)
+	}
+	if diff := cmp.Diff([]string{"1", "2", "10"}, cache.GetAvailabilityZones(context.Background())); diff != "" {
+		t.Error(diff)
+	}
+}
@@ -0,0 +1,58 @@
+package skewer
+
+import (
+	"context"
+	"strings"
+)
+
+// Annotations are consumer supplied metadata of a sku, for example an
+// approval status or a cost center.
+type Annotations map[string]string
+
+// AnnotatedSKU is a sku along with its annotations.
+type AnnotatedSKU struct {
+	SKU
+	Annotations Annotations
+}
+
+// WithOverlay is a functional option attaching annotations to skus,
+// keyed by sku name. Names are matched case-insensitively, so the
+// annotations apply to the sku in every location.
+func WithOverlay(overlay map[string]Annotations) Option {
+	return func(c *Config) (*Config, error) {
+		c.overlay = make(map[string]Annotations, len(overlay))
+		for name, annotations := range overlay {
+			c.overlay[strings.ToLower(name)] = annotations
+		}
+		return c, nil
+	}
+}
+
+// Annotations returns the annotations of the named sku, or nil when it
+// has none.
+func (c *Cache) Annotations(name string) Annotations {
+	return c.config.overlay[strings.ToLower(name)]
+}
+
+// AnnotationFilter produces a filter function matching skus annotated
+// with the key and value, e.g. AnnotationFilter("status", "approved").
+func (c *Cache) AnnotationFilter(key, value string) FilterFn {
+	return func(s *SKU) bool {
+		annotation, ok := c.Annotations(s.GetName())[key]
+		return ok && annotation == value
+	}
+}
+
+// ListAnnotated returns the skus matching all filters along with their
+// annotations.
+func (c *Cache) ListAnnotated(ctx context.Context, filters ...FilterFn) []AnnotatedSKU {
+	skus := c.List(ctx, filters...)
+	if skus == nil {
+		return nil
+	}
+	result := make([]AnnotatedSKU, len(skus))
+	for i := range skus {
+		result[i] = AnnotatedSKU{SKU: skus[i], Annotations: c.Annotations(skus[i].GetName())}
+	}
+	return result
+}
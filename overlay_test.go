@@ -0,0 +1,41 @@
+package skewer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_Cache_Overlay(t *testing.T) {
+	dataWrapper, err := newDataWrapper("./testdata/eastus.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache, err := NewStaticCache(Wrap(dataWrapper.Value), WithOverlay(map[string]Annotations{
+		"Standard_D4s_v3": {"status": "approved", "costCenter": "1234"},
+		"standard_nv6":    {"status": "banned"},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	if diff := cmp.Diff(Annotations{"status": "banned"}, cache.Annotations("Standard_NV6")); diff != "" {
+		t.Error(diff)
+	}
+	if got := cache.Annotations("Standard_D2_v2"); got != nil {
+		t.Errorf("expected no annotations, got %v", got)
+	}
+
+	annotated := cache.ListAnnotated(ctx, cache.AnnotationFilter("status", "approved"))
+	if diff := cmp.Diff(1, len(annotated)); diff != "" {
+		t.Fatal(diff)
+	}
+	if diff := cmp.Diff("Standard_D4s_v3", annotated[0].GetName()); diff != "" {
+		t.Error(diff)
+	}
+	if diff := cmp.Diff("1234", annotated[0].Annotations["costCenter"]); diff != "" {
+		t.Error(diff)
+	}
+}
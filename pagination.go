@@ -0,0 +1,84 @@
+package skewer
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"sort"
+)
+
+const (
+	// DefaultPageSize is used when a page request has no size.
+	DefaultPageSize = 100
+	// MaxPageSize is the largest page size served.
+	MaxPageSize = 1000
+)
+
+// PageRequest requests a page of filtered skus. Cursor is the opaque
+// NextCursor of the previous page, empty for the first page.
+type PageRequest struct {
+	Cursor string
+	Size   int
+}
+
+// Page is a page of skus. NextCursor is empty on the last page.
+type Page struct {
+	SKUs       []SKU
+	NextCursor string
+}
+
+// ErrInvalidCursor will be returned when a page cursor cannot be
+// decoded.
+type ErrInvalidCursor struct {
+	Cursor string
+}
+
+func (e *ErrInvalidCursor) Error() string {
+	return fmt.Sprintf("invalid page cursor '%s'", e.Cursor)
+}
+
+// ListPage returns one page of the skus matching filters, in the
+// canonical order of name, location and resource type. Cursors
+// identify the last sku of a page rather than an offset, so paging
+// stays stable when the cache refreshes between requests. Page sizes
+// are capped at MaxPageSize.
+func (c *Cache) ListPage(ctx context.Context, req PageRequest, filters ...FilterFn) (Page, error) {
+	size := req.Size
+	if size <= 0 {
+		size = DefaultPageSize
+	}
+	if size > MaxPageSize {
+		size = MaxPageSize
+	}
+
+	var after string
+	if req.Cursor != "" {
+		decoded, err := base64.RawURLEncoding.DecodeString(req.Cursor)
+		if err != nil {
+			return Page{}, &ErrInvalidCursor{Cursor: req.Cursor}
+		}
+		after = string(decoded)
+	}
+
+	// The data of the cache is in the canonical order of sort keys.
+	filtered := Filter(c.skus(ctx), filters...)
+	keys := make([]string, len(filtered))
+	for i := range filtered {
+		keys[i] = sortKey(&filtered[i])
+	}
+
+	start := sort.SearchStrings(keys, after)
+	for start < len(keys) && after != "" && keys[start] <= after {
+		start++
+	}
+	end := start + size
+	if end > len(filtered) {
+		end = len(filtered)
+	}
+
+	page := Page{SKUs: filtered[start:end]}
+	if end < len(filtered) {
+		page.NextCursor = base64.RawURLEncoding.EncodeToString([]byte(keys[end-1]))
+	}
+	return page, nil
+}
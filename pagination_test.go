@@ -0,0 +1,58 @@
+package skewer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-03-01/compute" //nolint:staticcheck
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_Cache_ListPage(t *testing.T) {
+	ctx := context.Background()
+	client := &fakeClient{skus: []compute.ResourceSku{
+		{Name: to.StringPtr("c"), ResourceType: to.StringPtr(VirtualMachines)},
+		{Name: to.StringPtr("a"), ResourceType: to.StringPtr(VirtualMachines)},
+		{Name: to.StringPtr("b"), ResourceType: to.StringPtr(VirtualMachines)},
+		{Name: to.StringPtr("d"), ResourceType: to.StringPtr(Disks)},
+	}}
+	cache, err := NewCache(ctx, WithClient(client))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first, err := cache.ListPage(ctx, PageRequest{Size: 2}, ResourceTypeFilter(VirtualMachines))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"a", "b"}, skuNames(first.SKUs)); diff != "" {
+		t.Error(diff)
+	}
+	if first.NextCursor == "" {
+		t.Fatal("expected a cursor for the next page")
+	}
+
+	// Refreshing with new data before the cursor must not shift pages.
+	client.skus = append(client.skus, compute.ResourceSku{Name: to.StringPtr("aa"), ResourceType: to.StringPtr(VirtualMachines)})
+	if err := cache.Refresh(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := cache.ListPage(ctx, PageRequest{Cursor: first.NextCursor, Size: 2}, ResourceTypeFilter(VirtualMachines))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"c"}, skuNames(second.SKUs)); diff != "" {
+		t.Error(diff)
+	}
+	if second.NextCursor != "" {
+		t.Errorf("expected last page, got cursor '%s'", second.NextCursor)
+	}
+
+	var errInvalidCursor *ErrInvalidCursor
+	if _, err := cache.ListPage(ctx, PageRequest{Cursor: "%%%"}); !errors.As(err, &errInvalidCursor) {
+		t.Errorf("expected invalid cursor error, got '%v'", err)
+	}
+}
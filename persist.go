@@ -0,0 +1,104 @@
+package skewer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// cacheFileVersion is the version of the on-disk format written by
+// Cache.Save. It changes only with incompatible changes of the format.
+const cacheFileVersion = 1
+
+// cacheFile is the on-disk format of a cache: the skus in canonical
+// order, encoded with SKU.MarshalJSON, and the time they were listed.
+type cacheFile struct {
+	Version  int       `json:"version"`
+	LoadedAt time.Time `json:"loadedAt"`
+	SKUs     []SKU     `json:"skus"`
+}
+
+// ErrCacheFileVersion will be returned when loading a cache file written
+// in an unknown format.
+type ErrCacheFileVersion struct {
+	Version int
+}
+
+func (e *ErrCacheFileVersion) Error() string {
+	return fmt.Sprintf("unsupported cache file version %d, expected %d", e.Version, cacheFileVersion)
+}
+
+// LoadedAt returns the time the data of the cache was listed, which is
+// preserved by Save and LoadCache.
+func (c *Cache) LoadedAt() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.loadedAt
+}
+
+// Snapshot returns the data of the cache and the time it was listed.
+func (c *Cache) Snapshot() Snapshot {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return Snapshot{Time: c.loadedAt, SKUs: append([]SKU(nil), c.data...)}
+}
+
+// Save writes the data of the cache to a file at path as json, with the
+// time it was listed, so tools can load it with LoadCache rather than
+// listing every sku on each invocation. The file is replaced atomically.
+func (c *Cache) Save(path string) error {
+	snapshot := c.Snapshot()
+	data, err := json.Marshal(cacheFile{
+		Version:  cacheFileVersion,
+		LoadedAt: snapshot.Time.UTC(),
+		SKUs:     snapshot.SKUs,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to encode cache")
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return errors.Wrap(err, "failed to create cache file")
+	}
+	defer os.Remove(tmp.Name()) //nolint:errcheck
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close() //nolint:errcheck,gosec
+		return errors.Wrap(err, "failed to write cache file")
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Wrap(err, "failed to write cache file")
+	}
+	return errors.Wrap(os.Rename(tmp.Name(), path), "failed to replace cache file")
+}
+
+// LoadCache instantiates a cache from a file written by Cache.Save. The
+// options apply as for NewStaticCache, except that a cache given a
+// client and WithTTL refreshes once the saved data is older than the
+// ttl, so a tool can reuse a recent file and list only when it is stale.
+func LoadCache(path string, opts ...Option) (*Cache, error) {
+	data, err := os.ReadFile(path) //nolint:gosec
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read cache file")
+	}
+	var file cacheFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, errors.Wrap(err, "failed to decode cache file")
+	}
+	if file.Version != cacheFileVersion {
+		return nil, &ErrCacheFileVersion{Version: file.Version}
+	}
+
+	c, err := NewStaticCache(file.SKUs, opts...)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	c.loadedAt = file.LoadedAt
+	c.mu.Unlock()
+	return c, nil
+}
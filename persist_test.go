@@ -0,0 +1,126 @@
+package skewer
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-03-01/compute" //nolint:staticcheck
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func Test_Cache_Save(t *testing.T) {
+	ctx := context.Background()
+	dataWrapper, err := newDataWrapper("./testdata/eastus.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)
+	clock := func(c *Config) (*Config, error) {
+		c.clock = func() time.Time { return now }
+		return c, nil
+	}
+	cache, err := NewCache(ctx, WithClient(&fakeClient{skus: dataWrapper.Value}), WithLocation("eastus"), clock)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "skus.json")
+	if err := cache.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	first, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadCache(path, WithLocation("eastus"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !loaded.LoadedAt().Equal(now) {
+		t.Errorf("expected saved time %s, got %s", now, loaded.LoadedAt())
+	}
+	sortCapabilities := cmpopts.SortSlices(func(a, b compute.ResourceSkuCapabilities) bool {
+		return *a.Name < *b.Name
+	})
+	if diff := cmp.Diff(cache.List(ctx), loaded.List(ctx), sortCapabilities, equateEmptyLists); diff != "" {
+		t.Errorf("expected and actual loaded skus mismatch: %s", diff)
+	}
+	if diff := cmp.Diff(cache.GetAvailabilityZones(ctx), loaded.GetAvailabilityZones(ctx)); diff != "" {
+		t.Errorf("expected and actual loaded zones mismatch: %s", diff)
+	}
+
+	if err := loaded.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	second, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(string(first), string(second)); diff != "" {
+		t.Errorf("expected saving a loaded cache to be stable: %s", diff)
+	}
+}
+
+func Test_LoadCache_Stale(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)
+	clock := func(c *Config) (*Config, error) {
+		c.clock = func() time.Time { return now }
+		return c, nil
+	}
+	client := &fakeClient{skus: []compute.ResourceSku{{Name: to.StringPtr("a"), Locations: &[]string{"eastus"}}}}
+	cache, err := NewCache(ctx, WithClient(client), clock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "skus.json")
+	if err := cache.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	client.skus = append(client.skus, compute.ResourceSku{Name: to.StringPtr("b"), Locations: &[]string{"eastus"}})
+	now = now.Add(30 * time.Minute)
+	loaded, err := LoadCache(path, WithClient(client), WithTTL(time.Hour), clock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := len(loaded.List(ctx)); got != 1 {
+		t.Errorf("expected recent file to be served without listing, got %d skus", got)
+	}
+
+	now = now.Add(30 * time.Minute)
+	if got := len(loaded.List(ctx)); got != 2 {
+		t.Errorf("expected stale file to be refreshed, got %d skus", got)
+	}
+}
+
+func Test_LoadCache_Errors(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := LoadCache(filepath.Join(dir, "missing.json")); err == nil {
+		t.Errorf("expected error loading a missing file")
+	}
+
+	invalid := filepath.Join(dir, "invalid.json")
+	if err := os.WriteFile(invalid, []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadCache(invalid); err == nil {
+		t.Errorf("expected error loading an invalid file")
+	}
+
+	future := filepath.Join(dir, "future.json")
+	if err := os.WriteFile(future, []byte(`{"version":2,"skus":[]}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	var versionErr *ErrCacheFileVersion
+	if _, err := LoadCache(future); !errors.As(err, &versionErr) {
+		t.Errorf("expected ErrCacheFileVersion, got %v", err)
+	}
+}
@@ -0,0 +1,186 @@
+package skewer
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// ZoneAllocation is the number of instances planned for a zone.
+type ZoneAllocation struct {
+	Zone  string
+	Count int
+}
+
+// ZonePreferences constrain and rank the zones of a plan.
+type ZonePreferences struct {
+	// Required restricts plans to these zones when not empty.
+	Required []string
+	// Preferred zones receive the instances which cannot be spread
+	// evenly, and rank skus available in them higher.
+	Preferred []string
+	// MinZones is the minimum number of zones a plan must spread over.
+	MinZones int
+}
+
+// ErrNoAvailableZones will be returned when a sku has no unrestricted
+// availability zones in a location.
+type ErrNoAvailableZones struct {
+	Name     string
+	Location string
+}
+
+func (e *ErrNoAvailableZones) Error() string {
+	return fmt.Sprintf("sku %s has no unrestricted availability zones in location %s", e.Name, e.Location)
+}
+
+// ErrInsufficientZones will be returned when a sku is available in
+// fewer zones than required by zone preferences.
+type ErrInsufficientZones struct {
+	Name     string
+	Location string
+	Zones    int
+	MinZones int
+}
+
+func (e *ErrInsufficientZones) Error() string {
+	return fmt.Sprintf("sku %s is available in %d zones of location %s, %d required", e.Name, e.Zones, e.Location, e.MinZones)
+}
+
+// ErrNegativeCount will be returned when planning or requesting quota
+// for a negative number of instances.
+type ErrNegativeCount struct {
+	Count int64
+}
+
+func (e *ErrNegativeCount) Error() string {
+	return fmt.Sprintf("invalid negative number of instances: %d", e.Count)
+}
+
+// PlanZoneSpread proposes a best-effort spread of count instances of
+// the sku over its unrestricted availability zones in location, e.g. 10
+// instances over zones 1 and 3 yields 5 and 5. Instances which cannot
+// be spread evenly go to the lowest zones first. The result is ordered
+// by zone.
+func PlanZoneSpread(sku *SKU, location string, count int) ([]ZoneAllocation, error) {
+	return PlanZoneSpreadWithPreferences(sku, location, count, ZonePreferences{})
+}
+
+// PlanZoneSpreadWithPreferences is like PlanZoneSpread, restricted to
+// the required zones. Instances which cannot be spread evenly go to the
+// preferred zones first, then the lowest zones.
+func PlanZoneSpreadWithPreferences(sku *SKU, location string, count int, prefs ZonePreferences) ([]ZoneAllocation, error) {
+	if count < 0 {
+		return nil, &ErrNegativeCount{Count: int64(count)}
+	}
+	zones := prefs.zones(sku, location)
+	if len(zones) == 0 {
+		return nil, &ErrNoAvailableZones{Name: sku.GetName(), Location: location}
+	}
+	if len(zones) < prefs.MinZones {
+		return nil, &ErrInsufficientZones{Name: sku.GetName(), Location: location, Zones: len(zones), MinZones: prefs.MinZones}
+	}
+
+	// Hand out the remainder in order of preference.
+	order := append([]string(nil), zones...)
+	preferred := prefs.preferred()
+	sort.SliceStable(order, func(i, j int) bool {
+		return preferred[order[i]] && !preferred[order[j]]
+	})
+	extra := make(map[string]bool, count%len(zones))
+	for _, zone := range order[:count%len(zones)] {
+		extra[zone] = true
+	}
+
+	result := make([]ZoneAllocation, 0, len(zones))
+	for _, zone := range zones {
+		share := count / len(zones)
+		if extra[zone] {
+			share++
+		}
+		result = append(result, ZoneAllocation{Zone: zone, Count: share})
+	}
+
+	return result, nil
+}
+
+// zones returns the sorted unrestricted zones of the sku in location
+// allowed by the preferences.
+func (p ZonePreferences) zones(sku *SKU, location string) []string {
+	available := sku.AvailabilityZones(location)
+	if len(p.Required) > 0 {
+		required := make(map[string]bool, len(p.Required))
+		for _, zone := range p.Required {
+			if available[zone] {
+				required[zone] = true
+			}
+		}
+		available = required
+	}
+	return sortedZones(available)
+}
+
+func (p ZonePreferences) preferred() map[string]bool {
+	preferred := make(map[string]bool, len(p.Preferred))
+	for _, zone := range p.Preferred {
+		preferred[zone] = true
+	}
+	return preferred
+}
+
+// Recommendation is a sku along with its planned zone assignment.
+type Recommendation struct {
+	SKU   SKU
+	Zones []ZoneAllocation
+}
+
+// RecommendZonal plans count instances for every virtual machine sku
+// matching filters which satisfies the zone preferences in the cache
+// location. Only zones planned at least one instance count toward the
+// spread: recommendations spreading over more zones rank first, then
+// those placing instances in more preferred zones, then in canonical
+// order. Skus whose plan spreads over fewer than MinZones are skipped.
+func (c *Cache) RecommendZonal(ctx context.Context, count int, prefs ZonePreferences, filters ...FilterFn) []Recommendation {
+	preferred := prefs.preferred()
+	type ranked struct {
+		Recommendation
+		spread    int
+		preferred int
+	}
+
+	var candidates []ranked
+	for _, sku := range c.List(ctx, append([]FilterFn{ResourceTypeFilter(VirtualMachines)}, filters...)...) {
+		sku := sku
+		zones, err := PlanZoneSpreadWithPreferences(&sku, c.skuLocation(&sku), count, prefs)
+		if err != nil {
+			continue
+		}
+		candidate := ranked{Recommendation: Recommendation{SKU: sku, Zones: zones}}
+		for _, allocation := range zones {
+			if allocation.Count == 0 {
+				continue
+			}
+			candidate.spread++
+			if preferred[allocation.Zone] {
+				candidate.preferred++
+			}
+		}
+		if candidate.spread < prefs.MinZones {
+			continue
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].spread != candidates[j].spread {
+			return candidates[i].spread > candidates[j].spread
+		}
+		return candidates[i].preferred > candidates[j].preferred
+	})
+
+	var result []Recommendation
+	for _, candidate := range candidates {
+		result = append(result, candidate.Recommendation)
+	}
+	return result
+}
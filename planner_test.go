@@ -0,0 +1,177 @@
+package skewer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-03-01/compute" //nolint:staticcheck
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_PlanZoneSpread(t *testing.T) {
+	newSKU := func(zones []string, restricted []string) SKU {
+		return SKU{
+			Name: to.StringPtr("foo"),
+			LocationInfo: &[]compute.ResourceSkuLocationInfo{
+				{
+					Location: to.StringPtr("baz"),
+					Zones:    &zones,
+				},
+			},
+			Restrictions: &[]compute.ResourceSkuRestrictions{
+				{
+					Type:            compute.Zone,
+					Values:          &[]string{"baz"},
+					RestrictionInfo: &compute.ResourceSkuRestrictionInfo{Zones: &restricted},
+				},
+			},
+		}
+	}
+
+	cases := map[string]struct {
+		sku    SKU
+		count  int
+		prefs  ZonePreferences
+		expect []ZoneAllocation
+		err    bool
+	}{
+		"should spread evenly over unrestricted zones": {
+			sku:    newSKU([]string{"3", "2", "1"}, []string{"2"}),
+			count:  10,
+			expect: []ZoneAllocation{{Zone: "1", Count: 5}, {Zone: "3", Count: 5}},
+		},
+		"should place remainder in lowest zones": {
+			sku:    newSKU([]string{"1", "2", "3"}, nil),
+			count:  5,
+			expect: []ZoneAllocation{{Zone: "1", Count: 2}, {Zone: "2", Count: 2}, {Zone: "3", Count: 1}},
+		},
+		"should place remainder in preferred zones": {
+			sku:    newSKU([]string{"1", "2", "3"}, nil),
+			count:  4,
+			prefs:  ZonePreferences{Preferred: []string{"3"}},
+			expect: []ZoneAllocation{{Zone: "1", Count: 1}, {Zone: "2", Count: 1}, {Zone: "3", Count: 2}},
+		},
+		"should only use required zones": {
+			sku:    newSKU([]string{"1", "2", "3"}, []string{"2"}),
+			count:  4,
+			prefs:  ZonePreferences{Required: []string{"1", "2"}},
+			expect: []ZoneAllocation{{Zone: "1", Count: 4}},
+		},
+		"should error with fewer zones than required": {
+			sku:   newSKU([]string{"1", "2", "3"}, []string{"2"}),
+			count: 4,
+			prefs: ZonePreferences{MinZones: 3},
+			err:   true,
+		},
+		"should error with a negative count": {
+			sku:   newSKU([]string{"1", "2", "3"}, nil),
+			count: -1,
+			err:   true,
+		},
+		"should error without unrestricted zones": {
+			sku:   newSKU([]string{"1"}, []string{"1"}),
+			count: 1,
+			err:   true,
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			got, err := PlanZoneSpreadWithPreferences(&tc.sku, "baz", tc.count, tc.prefs)
+			if tc.err != (err != nil) {
+				t.Fatalf("expected error %t, got '%v'", tc.err, err)
+			}
+			if diff := cmp.Diff(tc.expect, got); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func Test_Cache_RecommendZonal(t *testing.T) {
+	newSKU := func(name string, zones ...string) SKU {
+		return SKU{
+			Name:         to.StringPtr(name),
+			ResourceType: to.StringPtr(VirtualMachines),
+			Locations:    &[]string{"baz"},
+			LocationInfo: &[]compute.ResourceSkuLocationInfo{
+				{
+					Location: to.StringPtr("baz"),
+					Zones:    &zones,
+				},
+			},
+		}
+	}
+	cache, err := NewStaticCache([]SKU{
+		newSKU("a", "1"),
+		newSKU("b", "1", "2"),
+		newSKU("c", "2", "3"),
+		newSKU("d", "1", "2", "3"),
+	}, WithLocation("baz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := cache.RecommendZonal(context.Background(), 4, ZonePreferences{
+		Required:  []string{"1", "2"},
+		Preferred: []string{"2"},
+		MinZones:  1,
+	})
+
+	expect := []struct {
+		name  string
+		zones []ZoneAllocation
+	}{
+		{"b", []ZoneAllocation{{Zone: "1", Count: 2}, {Zone: "2", Count: 2}}},
+		{"d", []ZoneAllocation{{Zone: "1", Count: 2}, {Zone: "2", Count: 2}}},
+		{"c", []ZoneAllocation{{Zone: "2", Count: 4}}},
+		{"a", []ZoneAllocation{{Zone: "1", Count: 4}}},
+	}
+	if diff := cmp.Diff(len(expect), len(got)); diff != "" {
+		t.Fatal(diff)
+	}
+	for i := range expect {
+		if diff := cmp.Diff(expect[i].name, got[i].SKU.GetName()); diff != "" {
+			t.Error(diff)
+		}
+		if diff := cmp.Diff(expect[i].zones, got[i].Zones); diff != "" {
+			t.Error(diff)
+		}
+	}
+}
+
+func Test_Cache_RecommendZonal_CountsUsedZones(t *testing.T) {
+	newSKU := func(name string, zones ...string) SKU {
+		return SKU{
+			Name:         to.StringPtr(name),
+			ResourceType: to.StringPtr(VirtualMachines),
+			Locations:    &[]string{"baz"},
+			LocationInfo: &[]compute.ResourceSkuLocationInfo{
+				{
+					Location: to.StringPtr("baz"),
+					Zones:    &zones,
+				},
+			},
+		}
+	}
+	cache, err := NewStaticCache([]SKU{
+		newSKU("a", "1", "2"),
+		newSKU("b", "1", "2", "3"),
+	}, WithLocation("baz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A single instance uses one zone whatever the sku offers.
+	if got := cache.RecommendZonal(context.Background(), 1, ZonePreferences{MinZones: 2}); len(got) != 0 {
+		t.Errorf("expected no recommendation spreading one instance over 2 zones, got %d", len(got))
+	}
+	// Two instances use two zones of either sku, so the third zone of b
+	// does not rank it first.
+	got := cache.RecommendZonal(context.Background(), 2, ZonePreferences{})
+	if diff := cmp.Diff([]string{"a", "b"}, []string{got[0].SKU.GetName(), got[1].SKU.GetName()}); diff != "" {
+		t.Errorf("expected skus placing instances in as many zones to rank equally: %s", diff)
+	}
+}
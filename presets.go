@@ -0,0 +1,78 @@
+package skewer
+
+// Requirement presets bundle filters for common workloads. Each returns
+// a fresh slice, so callers may append their own filters to compose a
+// stricter requirement:
+//
+//	skus := cache.List(ctx, append(RequirementsGeneralPurposeK8sNode(), FamilyFilter("standardDSv3Family"))...)
+
+const (
+	k8sNodeMinVCPUs             = 2
+	k8sNodeMinMemoryMiB         = 4 * mebibytesPerGiB
+	databaseMinVCPUs            = 4
+	databaseMinMemoryMiBPerVCPU = 8 * mebibytesPerGiB
+)
+
+// RequirementsGeneralPurposeK8sNode selects virtual machines meeting
+// the minimum Kubernetes node size of 2 vCPUs and 4 GiB of memory,
+// without GPUs.
+func RequirementsGeneralPurposeK8sNode() []FilterFn {
+	return []FilterFn{
+		ResourceTypeFilter(VirtualMachines),
+		minimumFilter((*SKU).VCPU, k8sNodeMinVCPUs),
+		minimumFilter((*SKU).MemoryMiB, k8sNodeMinMemoryMiB),
+		withoutGPUs,
+	}
+}
+
+// RequirementsDatabaseHighIO selects virtual machines suited to IO
+// heavy databases: premium storage, accelerated networking and at
+// least 4 vCPUs with 8 GiB of memory per vCPU.
+func RequirementsDatabaseHighIO() []FilterFn {
+	return []FilterFn{
+		ResourceTypeFilter(VirtualMachines),
+		(*SKU).IsPremiumIO,
+		(*SKU).IsAcceleratedNetworkingSupported,
+		minimumFilter((*SKU).VCPU, databaseMinVCPUs),
+		memoryPerVCPUFilter(databaseMinMemoryMiBPerVCPU),
+	}
+}
+
+// RequirementsConfidential selects virtual machines supporting
+// confidential computing on Generation 2 images, which confidential
+// VM sizes require.
+func RequirementsConfidential() []FilterFn {
+	return []FilterFn{
+		ResourceTypeFilter(VirtualMachines),
+		(*SKU).IsConfidentialComputingSupported,
+		(*SKU).IsHyperVGen2Supported,
+	}
+}
+
+// minimumFilter produces a filter function requiring a quantity of at
+// least min. Skus failing to report the quantity do not match.
+func minimumFilter(quantity func(*SKU) (int64, error), min int64) FilterFn {
+	return func(s *SKU) bool {
+		value, err := quantity(s)
+		return err == nil && value >= min
+	}
+}
+
+// memoryPerVCPUFilter produces a filter function requiring at least
+// min MiB of memory per vCPU.
+func memoryPerVCPUFilter(min int64) FilterFn {
+	return func(s *SKU) bool {
+		vcpu, err := s.VCPU()
+		if err != nil || vcpu < 1 {
+			return false
+		}
+		memory, err := s.MemoryMiB()
+		return err == nil && memory >= min*vcpu
+	}
+}
+
+// withoutGPUs matches skus reporting no GPUs.
+func withoutGPUs(s *SKU) bool {
+	gpus, err := s.GPU()
+	return err != nil || gpus == 0
+}
@@ -0,0 +1,48 @@
+package skewer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_RequirementPresets(t *testing.T) {
+	dataWrapper, err := newDataWrapper("./testdata/eastus.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache, err := NewStaticCache(Wrap(dataWrapper.Value))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]struct {
+		filters []FilterFn
+		expect  []string
+	}{
+		"general purpose k8s node should exclude gpu skus": {
+			filters: RequirementsGeneralPurposeK8sNode(),
+			expect:  []string{"Standard_D13_v2_Promo", "Standard_D2_v2", "Standard_D4s_v3"},
+		},
+		"database high io should require premium io and memory per vcpu": {
+			filters: RequirementsDatabaseHighIO(),
+		},
+		"presets should compose with other filters": {
+			filters: append(RequirementsGeneralPurposeK8sNode(), (*SKU).IsPremiumIO),
+			expect:  []string{"Standard_D4s_v3"},
+		},
+		"confidential should require confidential computing": {
+			filters: RequirementsConfidential(),
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.expect, skuNames(cache.List(context.Background(), tc.filters...))); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package skewer
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// WithQueryCache is a functional option memoizing the results of Query
+// by key for ttl, for services repeating the same searches at high
+// rates. Memoized results are dropped when the cache refreshes.
+func WithQueryCache(ttl time.Duration) Option {
+	return func(c *Config) (*Config, error) {
+		c.queryTTL = ttl
+		return c, nil
+	}
+}
+
+// queryCache memoizes query results by key, for the generation of the
+// data they were computed from.
+type queryCache struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	now        func() time.Time
+	generation uint64
+	entries    map[string]queryEntry
+}
+
+type queryEntry struct {
+	generation uint64
+	expires    time.Time
+	skus       []SKU
+}
+
+func newQueryCache(ttl time.Duration, now func() time.Time) *queryCache {
+	return &queryCache{
+		ttl:     ttl,
+		now:     now,
+		entries: make(map[string]queryEntry),
+	}
+}
+
+// reset drops every memoized result, for data of a new generation. It
+// is a no-op without a query cache.
+func (q *queryCache) reset(generation uint64) {
+	if q == nil {
+		return
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.generation = generation
+	q.entries = make(map[string]queryEntry)
+}
+
+// get returns the unexpired result memoized under key for the
+// generation.
+func (q *queryCache) get(key string, generation uint64) ([]SKU, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	entry, ok := q.entries[key]
+	if !ok || entry.generation != generation || !q.now().Before(entry.expires) {
+		return nil, false
+	}
+	return entry.skus, true
+}
+
+// put memoizes the result under key, unless it was computed from data
+// other than the current generation, e.g. replaced by a concurrent
+// refresh.
+func (q *queryCache) put(key string, generation uint64, skus []SKU) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if generation != q.generation {
+		return
+	}
+	q.entries[key] = queryEntry{generation: generation, expires: q.now().Add(q.ttl), skus: skus}
+}
+
+// Query returns the skus matching all filters, like List. With
+// WithQueryCache, results are memoized under key, which must identify
+// the filters: calls sharing a key within the ttl return the first
+// result without filtering again. Callers must not modify the result.
+func (c *Cache) Query(ctx context.Context, key string, filters ...FilterFn) []SKU {
+	data, generation := c.generationView(ctx)
+	if c.queries == nil {
+		return Filter(data, filters...)
+	}
+
+	if skus, ok := c.queries.get(key, generation); ok {
+		return skus
+	}
+	skus := Filter(data, filters...)
+	c.queries.put(key, generation, skus)
+	return skus
+}
@@ -0,0 +1,151 @@
+package skewer
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-03-01/compute" //nolint:staticcheck
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_Cache_Query(t *testing.T) {
+	ctx := context.Background()
+	client := &fakeClient{skus: []compute.ResourceSku{{Name: to.StringPtr("foo")}, {Name: to.StringPtr("bar")}}}
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := func(c *Config) (*Config, error) {
+		c.clock = func() time.Time { return now }
+		return c, nil
+	}
+	cache, err := NewCache(ctx, WithClient(client), WithQueryCache(time.Minute), clock)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	calls := 0
+	counting := func(s *SKU) bool {
+		calls++
+		return s.GetName() == "foo"
+	}
+
+	for i := 0; i < 3; i++ {
+		if diff := cmp.Diff(1, len(cache.Query(ctx, "foo", counting))); diff != "" {
+			t.Fatal(diff)
+		}
+	}
+	if diff := cmp.Diff(2, calls); diff != "" {
+		t.Errorf("expected a single evaluation over 2 skus: %s", diff)
+	}
+
+	now = now.Add(time.Minute)
+	cache.Query(ctx, "foo", counting)
+	if diff := cmp.Diff(4, calls); diff != "" {
+		t.Errorf("expected expired result to be evaluated again: %s", diff)
+	}
+
+	if err := cache.Refresh(ctx); err != nil {
+		t.Fatal(err)
+	}
+	cache.Query(ctx, "foo", counting)
+	if diff := cmp.Diff(6, calls); diff != "" {
+		t.Errorf("expected refresh to drop memoized results: %s", diff)
+	}
+}
+
+func Test_Cache_QueryWithoutQueryCache(t *testing.T) {
+	cache, err := NewStaticCache(Wrap([]compute.ResourceSku{{Name: to.StringPtr("foo")}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	calls := 0
+	for i := 0; i < 2; i++ {
+		cache.Query(context.Background(), "foo", func(s *SKU) bool { calls++; return true })
+	}
+	if diff := cmp.Diff(2, calls); diff != "" {
+		t.Error(diff)
+	}
+}
+
+// flakyClient fails the next failures lists, then lists skus.
+type flakyClient struct {
+	mu       sync.Mutex
+	skus     []compute.ResourceSku
+	failures int
+}
+
+func (f *flakyClient) List(ctx context.Context, filter, includeExtendedLocations string) ([]compute.ResourceSku, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.failures > 0 {
+		f.failures--
+		return nil, errors.New("transient failure")
+	}
+	return f.skus, nil
+}
+
+func Test_Cache_QueryRefreshesStaleData(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := func(c *Config) (*Config, error) {
+		c.clock = func() time.Time { return now }
+		return c, nil
+	}
+	client := &flakyClient{skus: []compute.ResourceSku{{Name: to.StringPtr("foo")}}}
+	cache, err := NewCache(ctx, WithClient(client), WithTTL(time.Hour), WithQueryCache(time.Minute), clock)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A stale cache whose refresh fails once, then succeeds.
+	client.failures = 1
+	now = now.Add(2 * time.Hour)
+
+	done := make(chan []SKU)
+	go func() {
+		done <- cache.Query(ctx, "foo", NameFilter("foo"))
+	}()
+	select {
+	case skus := <-done:
+		if diff := cmp.Diff(1, len(skus)); diff != "" {
+			t.Error(diff)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("query deadlocked refreshing stale data")
+	}
+}
+
+func Test_Cache_QueryDuringRefresh(t *testing.T) {
+	ctx := context.Background()
+	client := &fakeClient{skus: []compute.ResourceSku{{Name: to.StringPtr("foo")}}}
+	cache, err := NewCache(ctx, WithClient(client), WithQueryCache(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The first evaluation refreshes the cache with a second matching
+	// sku, as a concurrent refresh landing during the query would.
+	refreshed := false
+	refreshing := func(s *SKU) bool {
+		if !refreshed {
+			refreshed = true
+			client.skus = append(client.skus, compute.ResourceSku{Name: to.StringPtr("foo")})
+			if err := cache.Refresh(ctx); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return s.GetName() == "foo"
+	}
+
+	if diff := cmp.Diff(1, len(cache.Query(ctx, "foo", refreshing))); diff != "" {
+		t.Errorf("expected the result of the data the query started with: %s", diff)
+	}
+	if diff := cmp.Diff(2, len(cache.Query(ctx, "foo", refreshing))); diff != "" {
+		t.Errorf("expected the result of the replaced data not to be memoized: %s", diff)
+	}
+	if diff := cmp.Diff(2, len(cache.Query(ctx, "foo", NameFilter("bar")))); diff != "" {
+		t.Errorf("expected the result of the current data to be memoized: %s", diff)
+	}
+}
@@ -0,0 +1,78 @@
+package skewer
+
+import (
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-03-01/compute" //nolint:staticcheck
+)
+
+// QuotaRequest contains the structured data needed to file a quota
+// increase or support request for a SKU in a location.
+type QuotaRequest struct {
+	// Location is the region the request targets.
+	Location string
+	// SKU is the name of the blocked SKU, e.g. "Standard_D4s_v3".
+	SKU string
+	// Family is the quota family of the SKU, e.g. "standardDSv3Family".
+	Family string
+	// CurrentUsage is the number of vCPUs already consumed in the family.
+	CurrentUsage int64
+	// CurrentLimit is the current vCPU limit of the family.
+	CurrentLimit int64
+	// RequestedLimit is the vCPU limit needed to deploy the instances.
+	RequestedLimit int64
+	// ReasonCodes lists the reasons of any restrictions for the location.
+	ReasonCodes []RestrictionReason
+}
+
+// NewQuotaRequest computes the quota request needed to deploy instances
+// of sku in location, given the subscription's compute usages for that
+// location. Quota is only considered when usages contain the family of
+// the sku. The returned boolean is false when neither a location
+// restriction nor insufficient quota blocks the deployment: zone
+// restrictions are reported in ReasonCodes, but the sku can still be
+// deployed regionally. It errors for a negative number of instances and
+// when the vCPU count of the sku cannot be determined.
+func NewQuotaRequest(sku *SKU, location string, usages []compute.Usage, instances int64) (QuotaRequest, bool, error) {
+	if instances < 0 {
+		return QuotaRequest{}, false, &ErrNegativeCount{Count: instances}
+	}
+	vcpu, err := sku.VCPU()
+	if err != nil {
+		return QuotaRequest{}, false, err
+	}
+
+	request := QuotaRequest{
+		Location: location,
+		SKU:      sku.GetName(),
+		Family:   sku.GetFamilyName(),
+	}
+
+	blocked := false
+	for _, restriction := range sku.locationRestrictions(location) {
+		request.ReasonCodes = append(request.ReasonCodes, RestrictionReason(restriction.ReasonCode))
+		if restriction.Type == compute.Location {
+			blocked = true
+		}
+	}
+
+	for _, usage := range usages {
+		if usage.Name == nil || usage.Name.Value == nil || !strings.EqualFold(*usage.Name.Value, request.Family) {
+			continue
+		}
+		if usage.CurrentValue != nil {
+			request.CurrentUsage = int64(*usage.CurrentValue)
+		}
+		if usage.Limit != nil {
+			request.CurrentLimit = *usage.Limit
+		}
+		request.RequestedLimit = request.CurrentLimit
+		if needed := request.CurrentUsage + vcpu*instances; needed > request.CurrentLimit {
+			request.RequestedLimit = needed
+			blocked = true
+		}
+		break
+	}
+
+	return request, blocked, nil
+}
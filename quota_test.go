@@ -0,0 +1,156 @@
+package skewer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-03-01/compute" //nolint:staticcheck
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_NewQuotaRequest(t *testing.T) {
+	capabilities := &[]compute.ResourceSkuCapabilities{
+		{
+			Name:  to.StringPtr(VCPUs),
+			Value: to.StringPtr("4"),
+		},
+	}
+	usages := []compute.Usage{
+		{
+			Name:         &compute.UsageName{Value: to.StringPtr("otherFamily")},
+			CurrentValue: to.Int32Ptr(0),
+			Limit:        to.Int64Ptr(0),
+		},
+		{
+			Name:         &compute.UsageName{Value: to.StringPtr("fooFamily")},
+			CurrentValue: to.Int32Ptr(8),
+			Limit:        to.Int64Ptr(10),
+		},
+	}
+
+	cases := map[string]struct {
+		sku       compute.ResourceSku
+		usages    []compute.Usage
+		instances int64
+		expect    QuotaRequest
+		blocked   bool
+		err       bool
+	}{
+		"should not be blocked without restrictions or usages": {
+			sku: compute.ResourceSku{
+				Name:         to.StringPtr("foo"),
+				Family:       to.StringPtr("fooFamily"),
+				Capabilities: capabilities,
+			},
+			instances: 1,
+			expect: QuotaRequest{
+				Location: "baz",
+				SKU:      "foo",
+				Family:   "fooFamily",
+			},
+		},
+		"should request enough quota for all instances": {
+			sku: compute.ResourceSku{
+				Name:         to.StringPtr("foo"),
+				Family:       to.StringPtr("fooFamily"),
+				Capabilities: capabilities,
+			},
+			usages:    usages,
+			instances: 2,
+			expect: QuotaRequest{
+				Location:       "baz",
+				SKU:            "foo",
+				Family:         "fooFamily",
+				CurrentUsage:   8,
+				CurrentLimit:   10,
+				RequestedLimit: 16,
+			},
+			blocked: true,
+		},
+		"should include restriction reasons for the location": {
+			sku: compute.ResourceSku{
+				Name:         to.StringPtr("foo"),
+				Family:       to.StringPtr("barFamily"),
+				Capabilities: capabilities,
+				Restrictions: &[]compute.ResourceSkuRestrictions{
+					{
+						Type:       compute.Location,
+						Values:     &[]string{"baz"},
+						ReasonCode: compute.NotAvailableForSubscription,
+					},
+					{
+						Type:       compute.Location,
+						Values:     &[]string{"other"},
+						ReasonCode: compute.QuotaID,
+					},
+				},
+			},
+			usages:    usages,
+			instances: 1,
+			expect: QuotaRequest{
+				Location:    "baz",
+				SKU:         "foo",
+				Family:      "barFamily",
+				ReasonCodes: []RestrictionReason{RestrictionReasonNotAvailableForSubscription},
+			},
+			blocked: true,
+		},
+		"should not be blocked by zone restrictions": {
+			sku: compute.ResourceSku{
+				Name:         to.StringPtr("foo"),
+				Family:       to.StringPtr("barFamily"),
+				Capabilities: capabilities,
+				Restrictions: &[]compute.ResourceSkuRestrictions{
+					{
+						Type:            compute.Zone,
+						Values:          &[]string{"baz"},
+						RestrictionInfo: &compute.ResourceSkuRestrictionInfo{Locations: &[]string{"baz"}, Zones: &[]string{"1"}},
+						ReasonCode:      compute.NotAvailableForSubscription,
+					},
+				},
+			},
+			instances: 1,
+			expect: QuotaRequest{
+				Location:    "baz",
+				SKU:         "foo",
+				Family:      "barFamily",
+				ReasonCodes: []RestrictionReason{RestrictionReasonNotAvailableForSubscription},
+			},
+		},
+		"should error for a negative number of instances": {
+			sku: compute.ResourceSku{
+				Name:         to.StringPtr("foo"),
+				Family:       to.StringPtr("fooFamily"),
+				Capabilities: capabilities,
+			},
+			usages:    usages,
+			instances: -1,
+			err:       true,
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			sku := SKU(tc.sku)
+			got, blocked, err := NewQuotaRequest(&sku, "baz", tc.usages, tc.instances)
+			if tc.err {
+				var errNegative *ErrNegativeCount
+				if !errors.As(err, &errNegative) {
+					t.Errorf("expected negative count error, got '%v'", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.expect, got); diff != "" {
+				t.Error(diff)
+			}
+			if diff := cmp.Diff(tc.blocked, blocked); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
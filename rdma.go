@@ -0,0 +1,48 @@
+package skewer
+
+// InfiniBandInfo describes the InfiniBand interconnect of an RDMA
+// capable VM size.
+type InfiniBandInfo struct {
+	// Generation is the InfiniBand generation, e.g. "HDR".
+	Generation string
+	// Gbps is the bandwidth of a single InfiniBand port.
+	Gbps int64
+	// Ports is the number of InfiniBand ports.
+	Ports int64
+}
+
+// infiniBandSeries maps RDMA capable series, keyed by family,
+// sub-family and version, to their InfiniBand interconnect.
+// See https://learn.microsoft.com/en-us/azure/virtual-machines/sizes-hpc
+var infiniBandSeries = map[string]InfiniBandInfo{
+	"H":     {"FDR", 56, 1},
+	"HB":    {"EDR", 100, 1},
+	"HC":    {"EDR", 100, 1},
+	"HB_v2": {"HDR", 200, 1},
+	"HB_v3": {"HDR", 200, 1},
+	"HB_v4": {"NDR", 400, 1},
+	"HX":    {"NDR", 400, 1},
+	"ND_v4": {"HDR", 200, 8},
+	"ND_v5": {"NDR", 400, 8},
+}
+
+// IsRdmaEnabled returns true when the VM size supports RDMA, usually
+// over InfiniBand.
+func (s *SKU) IsRdmaEnabled() bool {
+	return s.HasCapability(CapabilityRdmaEnabled)
+}
+
+// GetInfiniBandInfo returns the InfiniBand interconnect of an RDMA
+// enabled VM size, derived from its series since the API only exposes
+// RdmaEnabled. The boolean is false when the size is not RDMA enabled
+// or its series is unknown, see SetNameMetadataProvider.
+func (s *SKU) GetInfiniBandInfo() (InfiniBandInfo, bool) {
+	if !s.IsRdmaEnabled() {
+		return InfiniBandInfo{}, false
+	}
+	vmSize, err := s.GetVMSize()
+	if err != nil {
+		return InfiniBandInfo{}, false
+	}
+	return lookupInfiniBand(vmSize.seriesKey())
+}
@@ -0,0 +1,54 @@
+package skewer
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-03-01/compute" //nolint:staticcheck
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_SKU_GetInfiniBandInfo(t *testing.T) {
+	rdma := &[]compute.ResourceSkuCapabilities{
+		{Name: to.StringPtr(CapabilityRdmaEnabled), Value: to.StringPtr("True")},
+	}
+
+	cases := map[string]struct {
+		sku    SKU
+		expect InfiniBandInfo
+		found  bool
+	}{
+		"should not find infiniband without rdma": {
+			sku: SKU{Size: to.StringPtr("HB120rs_v3")},
+		},
+		"should not find infiniband for unknown series": {
+			sku: SKU{Size: to.StringPtr("D4s_v3"), Capabilities: rdma},
+		},
+		"should find infiniband from series and version": {
+			sku:    SKU{Size: to.StringPtr("HB120rs_v3"), Capabilities: rdma},
+			expect: InfiniBandInfo{Generation: "HDR", Gbps: 200, Ports: 1},
+			found:  true,
+		},
+		"should find infiniband for gpu series": {
+			sku:    SKU{Size: to.StringPtr("ND96asr_v4"), Capabilities: rdma},
+			expect: InfiniBandInfo{Generation: "HDR", Gbps: 200, Ports: 8},
+			found:  true,
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			got, found := tc.sku.GetInfiniBandInfo()
+			if diff := cmp.Diff(tc.expect, got); diff != "" {
+				t.Error(diff)
+			}
+			if diff := cmp.Diff(tc.found, found); diff != "" {
+				t.Error(diff)
+			}
+			if diff := cmp.Diff(tc.sku.Capabilities != nil, tc.sku.IsRdmaEnabled()); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package skewer
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// defaultRefreshJitter is the default fraction by which background
+// refresh intervals are randomized.
+const defaultRefreshJitter = 0.1
+
+// RefreshOption configures a background refresh, see StartRefresh.
+type RefreshOption func(*refreshConfig)
+
+type refreshConfig struct {
+	jitter   float64
+	callback func(error)
+}
+
+// WithJitter randomizes every refresh interval by up to the fraction
+// of it, in either direction, so many instances started at once do not
+// list from ARM at the same second. It defaults to 0.1.
+func WithJitter(fraction float64) RefreshOption {
+	return func(c *refreshConfig) {
+		c.jitter = fraction
+	}
+}
+
+// WithRefreshCallback calls fn after every background refresh with its
+// error, or nil on success.
+func WithRefreshCallback(fn func(error)) RefreshOption {
+	return func(c *refreshConfig) {
+		c.callback = fn
+	}
+}
+
+// StartRefresh refreshes the cache in the background every interval,
+// so reads never pay the latency of listing. It stops when ctx is
+// cancelled or the returned function is called, which waits for an
+// ongoing refresh to finish. A failed refresh keeps the previous data.
+// A non-positive interval starts nothing and returns a no-op stop.
+func (c *Cache) StartRefresh(ctx context.Context, interval time.Duration, opts ...RefreshOption) (stop func()) {
+	if interval <= 0 {
+		return func() {}
+	}
+
+	config := &refreshConfig{jitter: defaultRefreshJitter}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			timer := time.NewTimer(jittered(interval, config.jitter))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+			err := c.Refresh(ctx)
+			if config.callback != nil {
+				config.callback(err)
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		wg.Wait()
+	}
+}
+
+// jittered returns interval randomized by up to the fraction of it in
+// either direction.
+func jittered(interval time.Duration, fraction float64) time.Duration {
+	if fraction <= 0 {
+		return interval
+	}
+	offset := (rand.Float64()*2 - 1) * fraction * float64(interval) //nolint:gosec
+	return interval + time.Duration(offset)
+}
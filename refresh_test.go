@@ -0,0 +1,110 @@
+package skewer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-03-01/compute" //nolint:staticcheck
+	"github.com/Azure/go-autorest/autorest/to"
+)
+
+func Test_Cache_StartRefresh(t *testing.T) {
+	ctx := context.Background()
+	client := &fakeClient{skus: []compute.ResourceSku{{Name: to.StringPtr("a")}}}
+	cache, err := NewCache(ctx, WithClient(client))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The client is only changed before the refresher starts, so the
+	// background listing does not race with the test.
+	client.skus = append(client.skus, compute.ResourceSku{Name: to.StringPtr("b")})
+
+	results := make(chan error, 1)
+	stop := cache.StartRefresh(ctx, time.Millisecond, WithJitter(0.5), WithRefreshCallback(func(err error) {
+		select {
+		case results <- err:
+		default:
+		}
+	}))
+
+	select {
+	case err := <-results:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected background refresh to complete")
+	}
+	stop()
+
+	if got := cache.Len(ctx); got != 2 {
+		t.Errorf("expected refreshed data, got %d skus", got)
+	}
+}
+
+func Test_Cache_StartRefresh_Error(t *testing.T) {
+	ctx := context.Background()
+	cache, err := NewStaticCache(Wrap([]compute.ResourceSku{{Name: to.StringPtr("a")}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results := make(chan error, 1)
+	stop := cache.StartRefresh(ctx, time.Millisecond, WithRefreshCallback(func(err error) {
+		select {
+		case results <- err:
+		default:
+		}
+	}))
+	defer stop()
+
+	select {
+	case err := <-results:
+		if _, ok := err.(*ErrClientNil); !ok {
+			t.Errorf("expected client error, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected background refresh to complete")
+	}
+	if got := cache.Len(ctx); got != 1 {
+		t.Errorf("expected failed refresh to keep data, got %d skus", got)
+	}
+}
+
+func Test_Cache_StartRefresh_NonPositiveInterval(t *testing.T) {
+	ctx := context.Background()
+	cache, err := NewStaticCache(Wrap([]compute.ResourceSku{{Name: to.StringPtr("a")}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, interval := range []time.Duration{0, -time.Second} {
+		results := make(chan error, 1)
+		stop := cache.StartRefresh(ctx, interval, WithRefreshCallback(func(err error) {
+			select {
+			case results <- err:
+			default:
+			}
+		}))
+		select {
+		case <-results:
+			t.Errorf("expected no refresh with interval %s", interval)
+		case <-time.After(10 * time.Millisecond):
+		}
+		stop()
+	}
+}
+
+func Test_jittered(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		got := jittered(time.Second, 0.1)
+		if got < 900*time.Millisecond || got > 1100*time.Millisecond {
+			t.Fatalf("expected jittered interval within 10%%, got %s", got)
+		}
+	}
+	if got := jittered(time.Second, 0); got != time.Second {
+		t.Errorf("expected no jitter, got %s", got)
+	}
+}
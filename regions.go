@@ -0,0 +1,80 @@
+package skewer
+
+import (
+	"context"
+	"sort"
+)
+
+// RegionPreferences describes user supplied weights used to rank the
+// regions where a SKU is available. Region keys are compared after
+// normalization, so "East US" and "eastus" are equivalent.
+type RegionPreferences struct {
+	// LatencyTiers maps regions to a latency tier, where lower tiers
+	// are closer. Regions without a tier are treated as tier 0.
+	LatencyTiers map[string]int
+	// LatencyWeight is subtracted from the score once per latency tier.
+	LatencyWeight float64
+	// ResidencyGroups maps regions to a data residency group, for
+	// example "eu" or "us".
+	ResidencyGroups map[string]string
+	// AllowedResidencyGroups excludes all regions outside the listed
+	// residency groups when non-empty.
+	AllowedResidencyGroups []string
+	// PairedRegions maps regions to their paired region.
+	PairedRegions map[string]string
+	// PairedRegionBonus is added to the score of a region when the SKU
+	// is also available in its paired region.
+	PairedRegionBonus float64
+}
+
+// RankedRegion is a region with its preference score.
+type RankedRegion struct {
+	Location string
+	Score    float64
+}
+
+// RankRegions returns the regions where the named sku is available,
+// ordered by descending preference score and then by name.
+func (c *Cache) RankRegions(ctx context.Context, skuName string, prefs RegionPreferences) []RankedRegion {
+	available := make(map[string]bool)
+	for _, sku := range Filter(c.skus(ctx), NameFilter(skuName)) {
+		sku := sku
+		if sku.Locations == nil {
+			continue
+		}
+		for _, location := range *sku.Locations {
+			if sku.IsAvailableWithZones(location) {
+				available[normalizeLocation(location)] = true
+			}
+		}
+	}
+
+	latencyTiers := normalizeLocationKeys(prefs.LatencyTiers)
+	residencyGroups := normalizeLocationKeys(prefs.ResidencyGroups)
+	pairedRegions := normalizeLocationKeys(prefs.PairedRegions)
+	allowedGroups := make(map[string]bool, len(prefs.AllowedResidencyGroups))
+	for _, group := range prefs.AllowedResidencyGroups {
+		allowedGroups[normalizeLocation(group)] = true
+	}
+
+	result := make([]RankedRegion, 0, len(available))
+	for location := range available {
+		if len(allowedGroups) > 0 && !allowedGroups[normalizeLocation(residencyGroups[location])] {
+			continue
+		}
+		score := -prefs.LatencyWeight * float64(latencyTiers[location])
+		if pair, ok := pairedRegions[location]; ok && available[normalizeLocation(pair)] {
+			score += prefs.PairedRegionBonus
+		}
+		result = append(result, RankedRegion{Location: location, Score: score})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Score != result[j].Score {
+			return result[i].Score > result[j].Score
+		}
+		return result[i].Location < result[j].Location
+	})
+
+	return result
+}
@@ -0,0 +1,89 @@
+package skewer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-03-01/compute" //nolint:staticcheck
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func Test_Cache_RankRegions(t *testing.T) {
+	skuIn := func(location string, restricted bool) compute.ResourceSku {
+		sku := compute.ResourceSku{
+			Name:      to.StringPtr("foo"),
+			Locations: &[]string{location},
+			LocationInfo: &[]compute.ResourceSkuLocationInfo{
+				{
+					Location: to.StringPtr(location),
+				},
+			},
+		}
+		if restricted {
+			sku.Restrictions = &[]compute.ResourceSkuRestrictions{
+				{
+					Type:   compute.Location,
+					Values: &[]string{location},
+				},
+			}
+		}
+		return sku
+	}
+	data := Wrap([]compute.ResourceSku{
+		skuIn("eastus", false),
+		skuIn("westus", false),
+		skuIn("northeurope", false),
+		skuIn("westeurope", true),
+	})
+
+	cases := map[string]struct {
+		prefs  RegionPreferences
+		expect []RankedRegion
+	}{
+		"should order by name without preferences": {
+			expect: []RankedRegion{
+				{Location: "eastus"},
+				{Location: "northeurope"},
+				{Location: "westus"},
+			},
+		},
+		"should penalize latency and reward available pairs": {
+			prefs: RegionPreferences{
+				LatencyTiers:      map[string]int{"East US": 1, "northeurope": 2},
+				LatencyWeight:     1,
+				PairedRegions:     map[string]string{"westus": "eastus", "northeurope": "westeurope"},
+				PairedRegionBonus: 0.5,
+			},
+			expect: []RankedRegion{
+				{Location: "westus", Score: 0.5},
+				{Location: "eastus", Score: -1},
+				{Location: "northeurope", Score: -2},
+			},
+		},
+		"should exclude regions outside residency groups": {
+			prefs: RegionPreferences{
+				ResidencyGroups:        map[string]string{"northeurope": "eu", "westeurope": "eu"},
+				AllowedResidencyGroups: []string{"eu"},
+			},
+			expect: []RankedRegion{
+				{Location: "northeurope"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			cache, err := NewStaticCache(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := cache.RankRegions(context.Background(), "foo", tc.prefs)
+			if diff := cmp.Diff(tc.expect, got, cmpopts.EquateEmpty()); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,201 @@
+package skewer
+
+import (
+	"context"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-03-01/compute" //nolint:staticcheck
+)
+
+// Restriction is a flattened location or zone restriction of a sku,
+// with the reason the sku is blocked.
+type Restriction struct {
+	Type     compute.ResourceSkuRestrictionsType
+	Reason   RestrictionReason
+	Location string
+	Zones    []string
+}
+
+// GetRestrictions returns the restrictions of the sku which apply to
+// the location.
+func (s *SKU) GetRestrictions(location string) []Restriction {
+	restrictions := s.locationRestrictions(location)
+	if len(restrictions) == 0 {
+		return nil
+	}
+
+	result := make([]Restriction, 0, len(restrictions))
+	for _, restriction := range restrictions {
+		flattened := Restriction{
+			Type:     restriction.Type,
+			Reason:   RestrictionReason(restriction.ReasonCode),
+			Location: location,
+		}
+		if restriction.Type == compute.Zone && restriction.RestrictionInfo != nil && restriction.RestrictionInfo.Zones != nil {
+			zones := make(map[string]bool, len(*restriction.RestrictionInfo.Zones))
+			for _, zone := range *restriction.RestrictionInfo.Zones {
+				zones[zone] = true
+			}
+			flattened.Zones = sortedZones(zones)
+		}
+		result = append(result, flattened)
+	}
+	return result
+}
+
+// RestrictedZones returns the sorted zones of the location blocked by
+// zone restrictions, which AvailabilityZones subtracts. Location
+// restrictions block every zone and are reported by GetRestrictions
+// instead.
+func (s *SKU) RestrictedZones(location string) []string {
+	zones := map[string]bool{}
+	for _, restriction := range s.locationRestrictions(location) {
+		if restriction.Type != compute.Zone || restriction.RestrictionInfo == nil || restriction.RestrictionInfo.Zones == nil {
+			continue
+		}
+		for _, zone := range *restriction.RestrictionInfo.Zones {
+			zones[zone] = true
+		}
+	}
+	if len(zones) == 0 {
+		return nil
+	}
+	return sortedZones(zones)
+}
+
+// RestrictionReason is the reason code of a restriction, telling why a
+// sku is blocked for a subscription.
+type RestrictionReason compute.ResourceSkuRestrictionsReasonCode
+
+const (
+	// RestrictionReasonNotAvailableForSubscription denotes skus blocked
+	// for the subscription, typically for lack of capacity.
+	RestrictionReasonNotAvailableForSubscription = RestrictionReason(compute.NotAvailableForSubscription)
+	// RestrictionReasonQuotaID denotes skus blocked by the quota id of
+	// the subscription offer, e.g. free trials.
+	RestrictionReasonQuotaID = RestrictionReason(compute.QuotaID)
+)
+
+// IsQuota returns true when the restriction follows from the quota id
+// of the subscription offer, rather than from capacity.
+func (r RestrictionReason) IsQuota() bool {
+	return r == RestrictionReasonQuotaID
+}
+
+// Description explains the reason in terms suitable for customers.
+func (r RestrictionReason) Description() string {
+	switch r {
+	case RestrictionReasonNotAvailableForSubscription:
+		return "the size is not available for this subscription in this location, possibly due to capacity"
+	case RestrictionReasonQuotaID:
+		return "the size is not offered for this subscription type"
+	default:
+		return "the size is restricted: " + string(r)
+	}
+}
+
+// RestrictionReasons returns the distinct reasons of the location and
+// zone restrictions of the sku in the location, in API order.
+func (s *SKU) RestrictionReasons(location string) []RestrictionReason {
+	var reasons []RestrictionReason
+	seen := map[RestrictionReason]bool{}
+	for _, restriction := range s.GetRestrictions(location) {
+		reason := restriction.Reason
+		if !seen[reason] {
+			seen[reason] = true
+			reasons = append(reasons, reason)
+		}
+	}
+	return reasons
+}
+
+// RecheckPolicy maps restriction reasons to the interval after which
+// re-querying may lift the restriction. Reasons without an entry are
+// treated as permanent.
+type RecheckPolicy map[RestrictionReason]time.Duration
+
+// DefaultRecheckPolicy returns a policy which rechecks skus not
+// available for the subscription hourly, since capacity restrictions
+// fluctuate, and treats quota id restrictions as permanent because they
+// follow from the subscription offer.
+func DefaultRecheckPolicy() RecheckPolicy {
+	return RecheckPolicy{
+		RestrictionReasonNotAvailableForSubscription: time.Hour,
+	}
+}
+
+// RecheckHint advises controllers whether querying again may change
+// the availability of a sku.
+type RecheckHint struct {
+	// Recheck is true when re-querying may change the answer.
+	Recheck bool
+	// After is the suggested interval before re-querying.
+	After time.Duration
+	// Reasons lists the reasons of the restrictions in the location.
+	Reasons []RestrictionReason
+}
+
+// RecheckHint returns whether re-querying the availability of the sku
+// in the location might change the answer according to policy. Skus
+// which are unrestricted, or not offered in the location at all, never
+// need a recheck. A permanent reason prevails over transient ones.
+func (s *SKU) RecheckHint(location string, policy RecheckPolicy) RecheckHint {
+	hint := RecheckHint{}
+	if !s.HasLocation(location) {
+		return hint
+	}
+
+	for _, restriction := range s.GetRestrictions(location) {
+		hint.Reasons = append(hint.Reasons, restriction.Reason)
+	}
+	if len(hint.Reasons) == 0 {
+		return hint
+	}
+
+	for _, reason := range hint.Reasons {
+		after, ok := policy[reason]
+		if !ok {
+			return RecheckHint{Reasons: hint.Reasons}
+		}
+		if after > hint.After {
+			hint.After = after
+		}
+	}
+	hint.Recheck = true
+
+	return hint
+}
+
+// RestrictionReport groups the names of restricted skus by restriction
+// reason, then by family.
+type RestrictionReport map[RestrictionReason]map[string][]string
+
+// RestrictionReport lists every sku with a location or zone restriction
+// in the location, defaulting to the cache location, grouped by reason
+// and family. Names follow the canonical order of the cache.
+func (c *Cache) RestrictionReport(ctx context.Context, location string) RestrictionReport {
+	if location == "" {
+		location = c.config.location
+	}
+
+	report := RestrictionReport{}
+	data := c.skus(ctx)
+	for i := range data {
+		sku := &data[i]
+		seen := map[RestrictionReason]bool{}
+		for _, restriction := range sku.GetRestrictions(location) {
+			if seen[restriction.Reason] {
+				continue
+			}
+			seen[restriction.Reason] = true
+			families, ok := report[restriction.Reason]
+			if !ok {
+				families = map[string][]string{}
+				report[restriction.Reason] = families
+			}
+			family := sku.GetFamilyName()
+			families[family] = append(families[family], sku.GetName())
+		}
+	}
+	return report
+}
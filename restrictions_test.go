@@ -0,0 +1,161 @@
+package skewer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-03-01/compute" //nolint:staticcheck
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_SKU_GetRestrictions(t *testing.T) {
+	sku := SKU{
+		Restrictions: &[]compute.ResourceSkuRestrictions{
+			{
+				Type:       compute.Location,
+				Values:     &[]string{"baz"},
+				ReasonCode: compute.QuotaID,
+			},
+			{
+				Type:            compute.Zone,
+				Values:          &[]string{"baz"},
+				RestrictionInfo: &compute.ResourceSkuRestrictionInfo{Zones: &[]string{"1"}},
+				ReasonCode:      compute.NotAvailableForSubscription,
+			},
+			{
+				Type:       compute.Location,
+				Values:     &[]string{"other"},
+				ReasonCode: compute.QuotaID,
+			},
+		},
+	}
+
+	expect := []Restriction{
+		{Type: compute.Location, Reason: RestrictionReasonQuotaID, Location: "baz"},
+		{Type: compute.Zone, Reason: RestrictionReasonNotAvailableForSubscription, Location: "baz", Zones: []string{"1"}},
+	}
+	if diff := cmp.Diff(expect, sku.GetRestrictions("baz")); diff != "" {
+		t.Error(diff)
+	}
+
+	reasons := sku.RestrictionReasons("baz")
+	if diff := cmp.Diff([]RestrictionReason{RestrictionReasonQuotaID, RestrictionReasonNotAvailableForSubscription}, reasons); diff != "" {
+		t.Error(diff)
+	}
+	if !reasons[0].IsQuota() || reasons[1].IsQuota() {
+		t.Errorf("expected only quota id to be a quota restriction, got %v", reasons)
+	}
+	if sku.RestrictionReasons("unrestricted") != nil {
+		t.Error("expected no reasons in unrestricted location")
+	}
+}
+
+func Test_SKU_RestrictedZones(t *testing.T) {
+	sku := SKU{
+		Restrictions: &[]compute.ResourceSkuRestrictions{
+			{
+				Type:            compute.Zone,
+				Values:          &[]string{"baz"},
+				RestrictionInfo: &compute.ResourceSkuRestrictionInfo{Zones: &[]string{"3", "1"}},
+			},
+			{
+				Type:            compute.Zone,
+				Values:          &[]string{"baz"},
+				RestrictionInfo: &compute.ResourceSkuRestrictionInfo{Zones: &[]string{"1", "2"}},
+			},
+			{
+				Type:   compute.Location,
+				Values: &[]string{"other"},
+			},
+		},
+	}
+
+	if diff := cmp.Diff([]string{"1", "2", "3"}, sku.RestrictedZones("baz")); diff != "" {
+		t.Error(diff)
+	}
+	if sku.RestrictedZones("other") != nil {
+		t.Error("expected location restrictions not to list zones")
+	}
+}
+
+func Test_SKU_RecheckHint(t *testing.T) {
+	newSKU := func(reasons ...compute.ResourceSkuRestrictionsReasonCode) SKU {
+		restrictions := []compute.ResourceSkuRestrictions{}
+		for _, reason := range reasons {
+			restrictions = append(restrictions, compute.ResourceSkuRestrictions{
+				Type:       compute.Location,
+				Values:     &[]string{"baz"},
+				ReasonCode: reason,
+			})
+		}
+		return SKU{
+			Locations:    &[]string{"baz"},
+			Restrictions: &restrictions,
+		}
+	}
+
+	cases := map[string]struct {
+		sku      SKU
+		location string
+		expect   RecheckHint
+	}{
+		"should not recheck unrestricted sku": {
+			sku:      newSKU(),
+			location: "baz",
+		},
+		"should not recheck sku absent from location": {
+			sku:      newSKU(compute.NotAvailableForSubscription),
+			location: "other",
+		},
+		"should recheck capacity restrictions": {
+			sku:      newSKU(compute.NotAvailableForSubscription),
+			location: "baz",
+			expect: RecheckHint{
+				Recheck: true,
+				After:   time.Hour,
+				Reasons: []RestrictionReason{RestrictionReasonNotAvailableForSubscription},
+			},
+		},
+		"should not recheck permanent restrictions": {
+			sku:      newSKU(compute.NotAvailableForSubscription, compute.QuotaID),
+			location: "baz",
+			expect: RecheckHint{
+				Reasons: []RestrictionReason{RestrictionReasonNotAvailableForSubscription, RestrictionReasonQuotaID},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.expect, tc.sku.RecheckHint(tc.location, DefaultRecheckPolicy())); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func Test_Cache_RestrictionReport(t *testing.T) {
+	dataWrapper, err := newDataWrapper("./testdata/eastus.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache, err := NewStaticCache(Wrap(dataWrapper.Value), WithLocation("eastus"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect := RestrictionReport{
+		RestrictionReasonNotAvailableForSubscription: {
+			"standardDv2PromoFamily": {"Standard_D13_v2_Promo"},
+			"standardNVFamily":       {"Standard_NV6"},
+		},
+	}
+	if diff := cmp.Diff(expect, cache.RestrictionReport(context.Background(), "")); diff != "" {
+		t.Error(diff)
+	}
+	if diff := cmp.Diff(RestrictionReport{}, cache.RestrictionReport(context.Background(), "westus")); diff != "" {
+		t.Error(diff)
+	}
+}
@@ -0,0 +1,86 @@
+package skewer
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// retirements holds announced retirement dates of VM series without an
+// accelerator type in their name, keyed by lower case family,
+// sub-family and version as for gpuSeries.
+// See https://learn.microsoft.com/en-us/azure/virtual-machines/sizes-previous-gen
+var retirements = map[string]time.Time{
+	"a":     time.Date(2024, time.August, 31, 0, 0, 0, 0, time.UTC),
+	"nc":    time.Date(2023, time.September, 6, 0, 0, 0, 0, time.UTC),
+	"nc_v2": time.Date(2023, time.September, 6, 0, 0, 0, 0, time.UTC),
+	"nc_v3": time.Date(2025, time.September, 30, 0, 0, 0, 0, time.UTC),
+	"nd":    time.Date(2023, time.September, 6, 0, 0, 0, 0, time.UTC),
+	"nv":    time.Date(2023, time.September, 6, 0, 0, 0, 0, time.UTC),
+}
+
+var (
+	retirementsMu       sync.RWMutex
+	retirementsOverride map[string]time.Time
+)
+
+// retirementDateLayouts are the layouts RetirementDateUtc is parsed as.
+var retirementDateLayouts = []string{time.RFC3339, "2006-01-02", "01/02/2006"}
+
+// SetRetirements installs retirement dates consulted before the
+// built-in dataset, keyed by series like "NC_v3", so newly announced
+// retirements need no skewer release. A nil map restores the built-in
+// dataset only.
+func SetRetirements(series map[string]time.Time) {
+	var override map[string]time.Time
+	if series != nil {
+		override = make(map[string]time.Time, len(series))
+		for key, date := range series {
+			override[strings.ToLower(key)] = date
+		}
+	}
+	retirementsMu.Lock()
+	defer retirementsMu.Unlock()
+	retirementsOverride = override
+}
+
+// RetirementDate returns the announced retirement date of the VM size.
+// The RetirementDateUtc capability is used when the API reports it,
+// falling back to the maintained dataset of retiring series. The
+// boolean is false when no retirement has been announced.
+func (s *SKU) RetirementDate() (time.Time, bool) {
+	if value, err := s.GetCapabilityString(CapabilityRetirementDateUtc); err == nil {
+		for _, layout := range retirementDateLayouts {
+			if date, err := time.Parse(layout, value); err == nil {
+				return date, true
+			}
+		}
+	}
+
+	vmSize, err := s.GetVMSize()
+	if err != nil || vmSize.acceleratorType != nil {
+		return time.Time{}, false
+	}
+	key := strings.ToLower(vmSize.seriesKey())
+	retirementsMu.RLock()
+	date, ok := retirementsOverride[key]
+	retirementsMu.RUnlock()
+	if ok {
+		return date, true
+	}
+	date, ok = retirements[key]
+	return date, ok
+}
+
+// IsDeprecated returns true when a retirement has been announced for
+// the VM size, whether or not the date has passed.
+func (s *SKU) IsDeprecated() bool {
+	_, ok := s.RetirementDate()
+	return ok
+}
+
+// IsRetiredAt returns true when the VM size retires on or before t.
+func (s *SKU) IsRetiredAt(t time.Time) bool {
+	date, ok := s.RetirementDate()
+	return ok && !t.Before(date)
+}
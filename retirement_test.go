@@ -0,0 +1,88 @@
+package skewer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-03-01/compute" //nolint:staticcheck
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_SKU_RetirementDate(t *testing.T) {
+	cases := map[string]struct {
+		sku    compute.ResourceSku
+		expect time.Time
+		found  bool
+	}{
+		"should not find retirement for current size": {
+			sku: compute.ResourceSku{Size: to.StringPtr("D4s_v5")},
+		},
+		"should find retirement of series": {
+			sku:    compute.ResourceSku{Size: to.StringPtr("NC6s_v3")},
+			expect: time.Date(2025, time.September, 30, 0, 0, 0, 0, time.UTC),
+			found:  true,
+		},
+		"should find retirement of a series": {
+			sku:    compute.ResourceSku{Size: to.StringPtr("A1")},
+			expect: time.Date(2024, time.August, 31, 0, 0, 0, 0, time.UTC),
+			found:  true,
+		},
+		"should not match newer version of retiring series": {
+			sku: compute.ResourceSku{Size: to.StringPtr("A1_v2")},
+		},
+		"should not match sizes with accelerator type": {
+			sku: compute.ResourceSku{Size: to.StringPtr("NC4as_T4_v3")},
+		},
+		"should prefer capability reported by the api": {
+			sku: compute.ResourceSku{
+				Size: to.StringPtr("D4s_v5"),
+				Capabilities: &[]compute.ResourceSkuCapabilities{
+					{Name: to.StringPtr(CapabilityRetirementDateUtc), Value: to.StringPtr("2030-01-31")},
+				},
+			},
+			expect: time.Date(2030, time.January, 31, 0, 0, 0, 0, time.UTC),
+			found:  true,
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			sku := SKU(tc.sku)
+			got, found := sku.RetirementDate()
+			if diff := cmp.Diff(tc.expect, got); diff != "" {
+				t.Error(diff)
+			}
+			if diff := cmp.Diff(tc.found, found); diff != "" {
+				t.Error(diff)
+			}
+			if diff := cmp.Diff(tc.found, sku.IsDeprecated()); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func Test_SetRetirements(t *testing.T) {
+	defer SetRetirements(nil)
+
+	sku := &SKU{Size: to.StringPtr("D4s_v5")}
+	date := time.Date(2031, time.March, 1, 0, 0, 0, 0, time.UTC)
+	SetRetirements(map[string]time.Time{"D_v5": date})
+
+	if got, ok := sku.RetirementDate(); !ok || !got.Equal(date) {
+		t.Errorf("expected retirement on %s, got %s, %t", date, got, ok)
+	}
+	if sku.IsRetiredAt(date.Add(-time.Hour)) {
+		t.Error("expected sku not to be retired before its retirement date")
+	}
+	if !sku.IsRetiredAt(date) {
+		t.Error("expected sku to be retired on its retirement date")
+	}
+
+	SetRetirements(nil)
+	if sku.IsDeprecated() {
+		t.Error("expected override to be removed")
+	}
+}
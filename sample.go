@@ -0,0 +1,77 @@
+package skewer
+
+import (
+	"math/rand"
+	"sort"
+	"strings"
+)
+
+// Sample returns a reduced catalog of at most n skus, chosen
+// pseudo-randomly but deterministically for a seed. Skus are picked
+// round robin across families so every family is represented before
+// any family contributes a second sku. The returned skus keep their
+// original relative order. A sample of n <= 0 skus is empty.
+func Sample(skus []SKU, n int, seed int64) []SKU {
+	if skus == nil {
+		return nil
+	}
+	if n <= 0 {
+		return []SKU{}
+	}
+	if n >= len(skus) {
+		return append([]SKU(nil), skus...)
+	}
+
+	rng := rand.New(rand.NewSource(seed)) //nolint:gosec
+
+	// Group indexes by family, shuffling within and across families.
+	groups := make(map[string][]int)
+	for i := range skus {
+		family := strings.ToLower(skus[i].GetFamilyName())
+		groups[family] = append(groups[family], i)
+	}
+	families := make([]string, 0, len(groups))
+	for family := range groups {
+		families = append(families, family)
+	}
+	sort.Strings(families)
+	rng.Shuffle(len(families), func(i, j int) { families[i], families[j] = families[j], families[i] })
+	for _, family := range families {
+		indexes := groups[family]
+		rng.Shuffle(len(indexes), func(i, j int) { indexes[i], indexes[j] = indexes[j], indexes[i] })
+	}
+
+	picked := make([]int, 0, n)
+	for round := 0; len(picked) < n; round++ {
+		for _, family := range families {
+			if round < len(groups[family]) && len(picked) < n {
+				picked = append(picked, groups[family][round])
+			}
+		}
+	}
+	sort.Ints(picked)
+
+	result := make([]SKU, 0, len(picked))
+	for _, i := range picked {
+		result = append(result, skus[i])
+	}
+	return result
+}
+
+// GroupByFamily groups skus by family name, e.g.
+// "standardDSv3Family", which is also the quota bucket of virtual
+// machine skus. Skus keep their relative order within a family.
+func GroupByFamily(skus []SKU) map[string][]SKU {
+	groups := make(map[string][]SKU)
+	for i := range skus {
+		family := skus[i].GetFamilyName()
+		groups[family] = append(groups[family], skus[i])
+	}
+	return groups
+}
+
+// FilterToFamilies returns a new slice containing the skus belonging to
+// any of the provided families.
+func FilterToFamilies(skus []SKU, families ...string) []SKU {
+	return Filter(skus, FamilyFilter(families...))
+}
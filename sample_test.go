@@ -0,0 +1,90 @@
+package skewer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-03-01/compute" //nolint:staticcheck
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_Sample(t *testing.T) {
+	skus := Wrap([]compute.ResourceSku{
+		{Name: to.StringPtr("a1"), Family: to.StringPtr("a")},
+		{Name: to.StringPtr("a2"), Family: to.StringPtr("a")},
+		{Name: to.StringPtr("a3"), Family: to.StringPtr("a")},
+		{Name: to.StringPtr("b1"), Family: to.StringPtr("b")},
+		{Name: to.StringPtr("c1"), Family: to.StringPtr("c")},
+	})
+
+	t.Run("nil slice samples to nil slice", func(t *testing.T) {
+		if Sample(nil, 1, 0) != nil {
+			t.Error()
+		}
+	})
+
+	t.Run("non-positive sample is empty", func(t *testing.T) {
+		for _, n := range []int{0, -1} {
+			if diff := cmp.Diff([]SKU{}, Sample(skus, n, 0)); diff != "" {
+				t.Errorf("n=%d: %s", n, diff)
+			}
+		}
+	})
+
+	t.Run("large sample returns everything", func(t *testing.T) {
+		if diff := cmp.Diff(skus, Sample(skus, 10, 0)); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("sample is deterministic and covers all families", func(t *testing.T) {
+		sampled := Sample(skus, 3, 42)
+		if diff := cmp.Diff(sampled, Sample(skus, 3, 42)); diff != "" {
+			t.Error(diff)
+		}
+		families := map[string]bool{}
+		for i := range sampled {
+			families[sampled[i].GetFamilyName()] = true
+		}
+		if len(families) != 3 {
+			t.Errorf("expected 3 families, got %v", families)
+		}
+	})
+}
+
+func Test_GroupByFamily(t *testing.T) {
+	ctx := context.Background()
+	cache, err := NewStaticCache(Wrap([]compute.ResourceSku{
+		{Name: to.StringPtr("a2"), Family: to.StringPtr("aFamily"), ResourceType: to.StringPtr(VirtualMachines)},
+		{Name: to.StringPtr("b1"), Family: to.StringPtr("bFamily"), ResourceType: to.StringPtr(VirtualMachines)},
+		{Name: to.StringPtr("a1"), Family: to.StringPtr("aFamily"), ResourceType: to.StringPtr(VirtualMachines)},
+		{Name: to.StringPtr("d1"), Family: to.StringPtr("aFamily"), ResourceType: to.StringPtr(Disks)},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	names := map[string][]string{}
+	for family, skus := range cache.GroupByFamily(ctx, ResourceTypeFilter(VirtualMachines)) {
+		for i := range skus {
+			names[family] = append(names[family], skus[i].GetName())
+		}
+	}
+	expect := map[string][]string{"aFamily": {"a1", "a2"}, "bFamily": {"b1"}}
+	if diff := cmp.Diff(expect, names); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func Test_FilterToFamilies(t *testing.T) {
+	skus := Wrap([]compute.ResourceSku{
+		{Name: to.StringPtr("a1"), Family: to.StringPtr("aFamily")},
+		{Name: to.StringPtr("b1"), Family: to.StringPtr("bFamily")},
+		{Name: to.StringPtr("c1"), Family: to.StringPtr("cFamily")},
+	})
+	got := FilterToFamilies(skus, "AFAMILY", "cFamily")
+	if diff := cmp.Diff([]SKU{skus[0], skus[2]}, got); diff != "" {
+		t.Error(diff)
+	}
+}
@@ -0,0 +1,85 @@
+package skewer
+
+// WorkloadCategory is the workload a VM series is designed for, as
+// grouped in the Azure VM size documentation.
+type WorkloadCategory string
+
+const (
+	// WorkloadGeneralPurpose covers balanced sizes, e.g. A and D series.
+	WorkloadGeneralPurpose WorkloadCategory = "GeneralPurpose"
+	// WorkloadBurstable covers sizes accruing cpu credits, the B series.
+	WorkloadBurstable WorkloadCategory = "Burstable"
+	// WorkloadComputeOptimized covers sizes with a high cpu to memory
+	// ratio, the F series.
+	WorkloadComputeOptimized WorkloadCategory = "ComputeOptimized"
+	// WorkloadMemoryOptimized covers sizes with a high memory to cpu
+	// ratio, e.g. E and M series.
+	WorkloadMemoryOptimized WorkloadCategory = "MemoryOptimized"
+	// WorkloadStorageOptimized covers sizes with large local disks, the
+	// L series.
+	WorkloadStorageOptimized WorkloadCategory = "StorageOptimized"
+	// WorkloadGPU covers sizes with GPUs, the N series.
+	WorkloadGPU WorkloadCategory = "GPU"
+	// WorkloadHPC covers high performance compute sizes, the H series.
+	WorkloadHPC WorkloadCategory = "HPC"
+	// WorkloadUnknown is used for skus whose size cannot be parsed.
+	WorkloadUnknown WorkloadCategory = ""
+)
+
+// workloadFamilies maps VM size family letters to their workload.
+var workloadFamilies = map[string]WorkloadCategory{
+	"A": WorkloadGeneralPurpose,
+	"B": WorkloadBurstable,
+	"D": WorkloadGeneralPurpose,
+	"E": WorkloadMemoryOptimized,
+	"F": WorkloadComputeOptimized,
+	"G": WorkloadMemoryOptimized,
+	"H": WorkloadHPC,
+	"L": WorkloadStorageOptimized,
+	"M": WorkloadMemoryOptimized,
+	"N": WorkloadGPU,
+}
+
+// WorkloadCategory returns the workload the series of the VM size is
+// designed for, from the family letter of its name. Sizes with GPUs
+// are WorkloadGPU whatever their name.
+func (s *SKU) WorkloadCategory() WorkloadCategory {
+	if gpus, err := s.GPU(); err == nil && gpus > 0 {
+		return WorkloadGPU
+	}
+	vmSize, err := s.GetVMSize()
+	if err != nil {
+		return WorkloadUnknown
+	}
+	return workloadFamilies[vmSize.family]
+}
+
+// IsBurstable returns true for B series sizes.
+func (s *SKU) IsBurstable() bool {
+	return s.WorkloadCategory() == WorkloadBurstable
+}
+
+// IsGPUSeries returns true for N series sizes and any size with GPUs.
+func (s *SKU) IsGPUSeries() bool {
+	return s.WorkloadCategory() == WorkloadGPU
+}
+
+// IsHPCSeries returns true for H series sizes.
+func (s *SKU) IsHPCSeries() bool {
+	return s.WorkloadCategory() == WorkloadHPC
+}
+
+// IsMemoryOptimized returns true for E, G and M series sizes.
+func (s *SKU) IsMemoryOptimized() bool {
+	return s.WorkloadCategory() == WorkloadMemoryOptimized
+}
+
+// IsComputeOptimized returns true for F series sizes.
+func (s *SKU) IsComputeOptimized() bool {
+	return s.WorkloadCategory() == WorkloadComputeOptimized
+}
+
+// IsStorageOptimized returns true for L series sizes.
+func (s *SKU) IsStorageOptimized() bool {
+	return s.WorkloadCategory() == WorkloadStorageOptimized
+}
@@ -0,0 +1,79 @@
+package skewer
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-03-01/compute" //nolint:staticcheck
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_SKU_WorkloadCategory(t *testing.T) {
+	cases := map[string]struct {
+		sku    SKU
+		expect WorkloadCategory
+	}{
+		"unparsable size should be unknown": {
+			sku: SKU{Size: to.StringPtr("not a size")},
+		},
+		"d series should be general purpose": {
+			sku:    SKU{Size: to.StringPtr("D4s_v3")},
+			expect: WorkloadGeneralPurpose,
+		},
+		"b series should be burstable": {
+			sku:    SKU{Size: to.StringPtr("B2ms")},
+			expect: WorkloadBurstable,
+		},
+		"f series should be compute optimized": {
+			sku:    SKU{Size: to.StringPtr("F8s_v2")},
+			expect: WorkloadComputeOptimized,
+		},
+		"e series should be memory optimized": {
+			sku:    SKU{Size: to.StringPtr("E8-4s_v3")},
+			expect: WorkloadMemoryOptimized,
+		},
+		"l series should be storage optimized": {
+			sku:    SKU{Size: to.StringPtr("L8s_v3")},
+			expect: WorkloadStorageOptimized,
+		},
+		"n series should be gpu": {
+			sku:    SKU{Size: to.StringPtr("NC4as_T4_v3")},
+			expect: WorkloadGPU,
+		},
+		"h series should be hpc": {
+			sku:    SKU{Size: to.StringPtr("HB120rs_v3")},
+			expect: WorkloadHPC,
+		},
+		"gpus should be gpu regardless of name": {
+			sku: SKU{
+				Size: to.StringPtr("D4s_v3"),
+				Capabilities: &[]compute.ResourceSkuCapabilities{
+					{Name: to.StringPtr(GPUs), Value: to.StringPtr("1")},
+				},
+			},
+			expect: WorkloadGPU,
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.expect, tc.sku.WorkloadCategory()); diff != "" {
+				t.Error(diff)
+			}
+			got := map[WorkloadCategory]bool{
+				WorkloadBurstable:        tc.sku.IsBurstable(),
+				WorkloadGPU:              tc.sku.IsGPUSeries(),
+				WorkloadHPC:              tc.sku.IsHPCSeries(),
+				WorkloadMemoryOptimized:  tc.sku.IsMemoryOptimized(),
+				WorkloadComputeOptimized: tc.sku.IsComputeOptimized(),
+				WorkloadStorageOptimized: tc.sku.IsStorageOptimized(),
+			}
+			for category, ok := range got {
+				if ok != (category == tc.expect) {
+					t.Errorf("expected %s to be %t", category, category == tc.expect)
+				}
+			}
+		})
+	}
+}
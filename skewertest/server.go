@@ -0,0 +1,240 @@
+// Package skewertest provides an ARM compatible mock of the resource sku
+// endpoint, to test consumers of skewer end to end against a real
+// compute.ResourceSkusClient with injected faults.
+package skewertest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-03-01/compute" //nolint:staticcheck
+)
+
+// skipTokenParam carries the index of the first sku of a page in the
+// next link, like ARM's opaque $skiptoken.
+const skipTokenParam = "$skiptoken"
+
+// Server serves the resource skus of a subscription in pages of a fixed
+// size, following the ARM list protocol with next links. Faults can be
+// injected at any time, e.g. while a cache refreshes in the background.
+type Server struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	skus      []compute.ResourceSku
+	pageSize  int
+	throttle  int
+	failPage  int
+	pageCalls int
+	requests  int
+}
+
+// NewServer starts a server listing skus in pages of pageSize, or in a
+// single page when pageSize is not positive. Close it when done.
+func NewServer(skus []compute.ResourceSku, pageSize int) *Server {
+	s := &Server{
+		skus:     skus,
+		pageSize: pageSize,
+		failPage: -1,
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Client returns a resource sku client for the subscription against the
+// server. Retries back off for a millisecond instead of ARM's default
+// seconds, so throttling does not slow tests down.
+func (s *Server) Client(subscriptionID string) compute.ResourceSkusClient {
+	client := compute.NewResourceSkusClientWithBaseURI(s.URL, subscriptionID)
+	client.RetryDuration = time.Millisecond
+	return client
+}
+
+// SetSKUs replaces the skus served by subsequent requests.
+func (s *Server) SetSKUs(skus []compute.ResourceSku) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.skus = skus
+}
+
+// Throttle responds to the next n requests with 429 Too Many Requests.
+func (s *Server) Throttle(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.throttle = n
+}
+
+// FailPage responds to every request for the zero based page with 500
+// Internal Server Error, so listings end with a partial result. A
+// negative page stops failing.
+func (s *Server) FailPage(page int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failPage = page
+}
+
+// Requests returns the number of requests served, including faults.
+func (s *Server) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests++
+
+	if !strings.HasSuffix(r.URL.Path, "/providers/Microsoft.Compute/skus") {
+		writeError(w, http.StatusNotFound, "NotFound", "unknown path "+r.URL.Path)
+		return
+	}
+	if s.throttle > 0 {
+		s.throttle--
+		writeError(w, http.StatusTooManyRequests, "TooManyRequests", "throttled")
+		return
+	}
+
+	skus := filterLocation(s.skus, r.URL.Query().Get("$filter"))
+	if !strings.EqualFold(r.URL.Query().Get("includeExtendedLocations"), "true") {
+		skus = withoutExtendedLocations(skus)
+	}
+	start, err := strconv.Atoi(r.URL.Query().Get(skipTokenParam))
+	if err != nil || start < 0 || start > len(skus) {
+		start = 0
+	}
+	if s.pageSize > 0 && start/s.pageSize == s.failPage {
+		writeError(w, http.StatusInternalServerError, "InternalServerError", "injected page failure")
+		return
+	}
+
+	end := len(skus)
+	if s.pageSize > 0 && start+s.pageSize < end {
+		end = start + s.pageSize
+	}
+	result := compute.ResourceSkusResult{Value: &[]compute.ResourceSku{}}
+	*result.Value = append(*result.Value, skus[start:end]...)
+	if end < len(skus) {
+		next := *r.URL
+		next.Scheme, next.Host = "http", r.Host
+		query := next.Query()
+		query.Set(skipTokenParam, strconv.Itoa(end))
+		next.RawQuery = query.Encode()
+		nextLink := next.String()
+		result.NextLink = &nextLink
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+// filterLocation applies the only filter supported by ARM, of the form
+// "location eq 'eastus'".
+func filterLocation(skus []compute.ResourceSku, filter string) []compute.ResourceSku {
+	location := strings.TrimPrefix(filter, "location eq ")
+	if location == filter {
+		return skus
+	}
+	location = strings.Trim(location, "'")
+
+	var result []compute.ResourceSku
+	for i := range skus {
+		if skus[i].Locations == nil {
+			continue
+		}
+		for _, candidate := range *skus[i].Locations {
+			if strings.EqualFold(candidate, location) {
+				result = append(result, skus[i])
+				break
+			}
+		}
+	}
+	return result
+}
+
+// withoutExtendedLocations drops the extended locations of the skus,
+// which ARM only lists when includeExtendedLocations is true.
+func withoutExtendedLocations(skus []compute.ResourceSku) []compute.ResourceSku {
+	result := make([]compute.ResourceSku, len(skus))
+	for i := range skus {
+		result[i] = skus[i]
+		if skus[i].LocationInfo == nil {
+			continue
+		}
+		infos := make([]compute.ResourceSkuLocationInfo, len(*skus[i].LocationInfo))
+		for j, info := range *skus[i].LocationInfo {
+			info.ExtendedLocations = nil
+			infos[j] = info
+		}
+		result[i].LocationInfo = &infos
+	}
+	return result
+}
+
+// writeJSON writes the result as json. The sdk models omit their read
+// only fields when marshaling, which are most fields of a sku, so the
+// result is converted to its wire representation first.
+func writeJSON(w http.ResponseWriter, status int, result compute.ResourceSkusResult) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(wire(reflect.ValueOf(result)))
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"error": map[string]string{"code": code, "message": message},
+	})
+}
+
+// wire converts a value to plain maps and slices following its json
+// tags, bypassing the MarshalJSON methods of the sdk models.
+func wire(v reflect.Value) interface{} {
+	switch v.Kind() { //nolint:exhaustive
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return wire(v.Elem())
+	case reflect.Slice:
+		if v.IsNil() {
+			return nil
+		}
+		result := make([]interface{}, v.Len())
+		for i := range result {
+			result[i] = wire(v.Index(i))
+		}
+		return result
+	case reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+		result := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			result[iter.Key().String()] = wire(iter.Value())
+		}
+		return result
+	case reflect.Struct:
+		result := map[string]interface{}{}
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			name := strings.Split(field.Tag.Get("json"), ",")[0]
+			if name == "" || name == "-" || !field.IsExported() {
+				continue
+			}
+			if value := wire(v.Field(i)); value != nil {
+				result[name] = value
+			}
+		}
+		return result
+	default:
+		return v.Interface()
+	}
+}
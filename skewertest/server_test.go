@@ -0,0 +1,166 @@
+package skewertest
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-03-01/compute" //nolint:staticcheck
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/Azure/skewer"
+	"github.com/google/go-cmp/cmp"
+)
+
+func loadSKUs(t *testing.T, path string) []compute.ResourceSku {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var wrapper struct {
+		Value []compute.ResourceSku `json:"value"`
+	}
+	if err := json.Unmarshal(data, &wrapper); err != nil {
+		t.Fatal(err)
+	}
+	return wrapper.Value
+}
+
+func names(skus []skewer.SKU) []string {
+	result := make([]string, len(skus))
+	for i := range skus {
+		result[i] = skus[i].GetName()
+	}
+	return result
+}
+
+func Test_Server(t *testing.T) {
+	ctx := context.Background()
+	skus := loadSKUs(t, "../testdata/eastus.json")
+
+	newCache := func(t *testing.T, server *Server) (*skewer.Cache, error) {
+		t.Helper()
+		return skewer.NewCache(ctx, skewer.WithResourceClient(server.Client("subscription")), skewer.WithLocation("eastus"))
+	}
+
+	t.Run("should list all pages", func(t *testing.T) {
+		server := NewServer(skus, 1)
+		defer server.Close()
+
+		cache, err := newCache(t, server)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := []string{"Standard_D13_v2_Promo", "Standard_D2_v2", "Standard_D4s_v3", "Standard_NV6"}
+		if diff := cmp.Diff(expected, names(cache.List(ctx))); diff != "" {
+			t.Error(diff)
+		}
+		sku, err := cache.Get(ctx, "Standard_D4s_v3", skewer.VirtualMachines, "eastus")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(skus[2].Capabilities, sku.Capabilities); diff != "" {
+			t.Error(diff)
+		}
+		if diff := cmp.Diff(skus[2].LocationInfo, sku.LocationInfo); diff != "" {
+			t.Error(diff)
+		}
+		if server.Requests() != len(skus) {
+			t.Errorf("expected %d requests, got %d", len(skus), server.Requests())
+		}
+	})
+
+	t.Run("should apply location filter", func(t *testing.T) {
+		westus := []compute.ResourceSku{{Name: to.StringPtr("a"), Locations: &[]string{"westus"}}}
+		server := NewServer(append(westus, skus...), 0)
+		defer server.Close()
+
+		cache, err := newCache(t, server)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(cache.List(ctx)) != len(skus) {
+			t.Errorf("expected %d skus, got %d", len(skus), len(cache.List(ctx)))
+		}
+	})
+
+	t.Run("should only list extended locations when requested", func(t *testing.T) {
+		edge := []compute.ResourceSku{{
+			Name:      to.StringPtr("edge"),
+			Locations: &[]string{"westus"},
+			LocationInfo: &[]compute.ResourceSkuLocationInfo{
+				{Location: to.StringPtr("westus"), ExtendedLocations: &[]string{"losangeles"}},
+			},
+		}}
+		server := NewServer(edge, 0)
+		defer server.Close()
+
+		for _, extended := range []bool{false, true} {
+			opts := []skewer.Option{skewer.WithResourceClient(server.Client("subscription"))}
+			if extended {
+				opts = append(opts, skewer.WithExtendedLocations())
+			}
+			cache, err := skewer.NewCache(ctx, opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			skus := cache.List(ctx)
+			if len(skus) != 1 {
+				t.Fatalf("expected 1 sku, got %d", len(skus))
+			}
+			if got := skus[0].IsAvailableInExtendedLocation("losangeles"); got != extended {
+				t.Errorf("expected extended location availability %t, got %t", extended, got)
+			}
+		}
+	})
+
+	t.Run("should retry throttled requests", func(t *testing.T) {
+		server := NewServer(skus, 2)
+		defer server.Close()
+		server.Throttle(2)
+
+		cache, err := newCache(t, server)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(cache.List(ctx)) != len(skus) {
+			t.Errorf("expected %d skus, got %d", len(skus), len(cache.List(ctx)))
+		}
+	})
+
+	t.Run("should fail on partial listing and keep previous data", func(t *testing.T) {
+		server := NewServer(skus, 2)
+		defer server.Close()
+
+		cache, err := newCache(t, server)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		server.SetSKUs(skus[:1])
+		server.FailPage(1)
+		if err := cache.Refresh(ctx); err != nil {
+			t.Fatalf("expected single page listing to succeed, got %s", err)
+		}
+		if len(cache.List(ctx)) != 1 {
+			t.Errorf("expected 1 sku, got %d", len(cache.List(ctx)))
+		}
+
+		server.SetSKUs(skus)
+		if err := cache.Refresh(ctx); err == nil {
+			t.Fatal("expected partial listing to fail")
+		}
+		if len(cache.List(ctx)) != 1 {
+			t.Errorf("expected previous data to be kept, got %d skus", len(cache.List(ctx)))
+		}
+
+		server.FailPage(-1)
+		if err := cache.Refresh(ctx); err != nil {
+			t.Fatal(err)
+		}
+		if len(cache.List(ctx)) != len(skus) {
+			t.Errorf("expected %d skus, got %d", len(skus), len(cache.List(ctx)))
+		}
+	})
+}
@@ -2,6 +2,7 @@ package skewer
 
 import (
 	"fmt"
+	"math/big"
 	"strconv"
 	"strings"
 
@@ -49,6 +50,38 @@ func (s *SKU) VCPU() (int64, error) {
 	return s.GetCapabilityIntegerQuantity(VCPUs)
 }
 
+// VCPUsAvailable returns the number of vCPUs available to the guest,
+// which is lower than VCPU on constrained core sizes.
+func (s *SKU) VCPUsAvailable() (int64, error) {
+	return s.GetCapabilityIntegerQuantity(CapabilityVCPUsAvailable)
+}
+
+// VCPUsPerCore returns the number of vCPUs per physical core, e.g. 2
+// for sizes with hyper-threading.
+func (s *SKU) VCPUsPerCore() (int64, error) {
+	return s.GetCapabilityIntegerQuantity(CapabilityVCPUsPerCore)
+}
+
+// IsConstrainedCoreSKU returns true for constrained core sizes such as
+// Standard_E8-4s_v3, which expose fewer vCPUs to the guest than the
+// size is built from. It compares VCPUsAvailable with VCPU, falling
+// back to the size name when either is missing.
+func (s *SKU) IsConstrainedCoreSKU() bool {
+	vcpus, vcpuErr := s.VCPU()
+	available, availableErr := s.VCPUsAvailable()
+	if vcpuErr == nil && availableErr == nil {
+		return available < vcpus
+	}
+	vmSize, err := s.GetVMSize()
+	return err == nil && vmSize.cpusConstrained != nil
+}
+
+// ACUs returns the azure compute units per vCPU of this VM size, a
+// measure of relative cpu performance across sizes.
+func (s *SKU) ACUs() (int64, error) {
+	return s.GetCapabilityIntegerQuantity(CapabilityACUs)
+}
+
 // GPU returns the number of GPU this SKU supports.
 func (s *SKU) GPU() (int64, error) {
 	return s.GetCapabilityIntegerQuantity(GPUs)
@@ -59,24 +92,153 @@ func (s *SKU) Memory() (float64, error) {
 	return s.GetCapabilityFloatQuantity(MemoryGB)
 }
 
+// MemoryMiB returns the amount of memory this SKU supports in MiB. The
+// decimal MemoryGB value is converted exactly, treating GB as GiB like
+// the API does, so values such as "3.5" yield 3584 rather than drifting
+// through floating point. Fractions of a MiB are truncated.
+func (s *SKU) MemoryMiB() (int64, error) {
+	return s.memoryIn(mebibytesPerGiB)
+}
+
+// MemoryBytes returns the amount of memory this SKU supports in bytes,
+// converted exactly like MemoryMiB.
+func (s *SKU) MemoryBytes() (int64, error) {
+	return s.memoryIn(bytesPerGiB)
+}
+
+// memoryIn converts MemoryGB exactly to a unit of which there are
+// unitsPerGiB in a GiB, truncating fractions.
+func (s *SKU) memoryIn(unitsPerGiB int64) (int64, error) {
+	value, err := s.GetCapabilityString(MemoryGB)
+	if err != nil {
+		return -1, err
+	}
+	gib, ok := new(big.Rat).SetString(value)
+	if !ok {
+		return -1, &ErrCapabilityValueParse{MemoryGB, value, fmt.Errorf("invalid decimal")}
+	}
+	units := new(big.Rat).Mul(gib, big.NewRat(unitsPerGiB, 1))
+	return new(big.Int).Quo(units.Num(), units.Denom()).Int64(), nil
+}
+
+// MaxDataDisks returns the maximum number of data disks this SKU
+// supports.
+func (s *SKU) MaxDataDisks() (int64, error) {
+	return s.GetCapabilityIntegerQuantity(CapabilityMaxDataDiskCount)
+}
+
+// MaxNICs returns the maximum number of network interfaces this SKU
+// supports. The API publishes a single limit, which also applies to
+// interfaces with accelerated networking.
+func (s *SKU) MaxNICs() (int64, error) {
+	return s.GetCapabilityIntegerQuantity(CapabilityMaxNetworkInterfaces)
+}
+
 // MaxCachedDiskBytes returns the number of bytes available for the
 // cache if it exists on this VM size.
 func (s *SKU) MaxCachedDiskBytes() (int64, error) {
 	return s.GetCapabilityIntegerQuantity(CachedDiskBytes)
 }
 
+// MaxCachedDiskGiB returns the size of the cache in GiB if it exists on
+// this VM size.
+func (s *SKU) MaxCachedDiskGiB() (float64, error) {
+	bytes, err := s.MaxCachedDiskBytes()
+	if err != nil {
+		return -1, err
+	}
+	return float64(bytes) / bytesPerGiB, nil
+}
+
 // MaxResourceVolumeMB returns the number of bytes available for the
 // cache if it exists on this VM size.
 func (s *SKU) MaxResourceVolumeMB() (int64, error) {
 	return s.GetCapabilityIntegerQuantity(MaxResourceVolumeMB)
 }
 
+// TempDiskBytes returns the size of the temporary disk in bytes. The
+// API reports MaxResourceVolumeMB in MiB, e.g. 32768 for 32 GiB.
+func (s *SKU) TempDiskBytes() (int64, error) {
+	mib, err := s.MaxResourceVolumeMB()
+	if err != nil {
+		return -1, err
+	}
+	return mib * bytesPerMiB, nil
+}
+
+// OSVhdSizeMB returns the maximum size of the os disk in MB on this VM
+// size.
+func (s *SKU) OSVhdSizeMB() (int64, error) {
+	return s.GetCapabilityIntegerQuantity(CapabilityOSVhdSizeMB)
+}
+
+// IsNVMeSupported returns true when the VM size supports attaching
+// disks through an NVMe controller.
+func (s *SKU) IsNVMeSupported() bool {
+	return s.HasCapabilityWithSeparator(CapabilityDiskControllerTypes, DiskControllerTypeNVMe)
+}
+
+// NvmeDiskSizeInMiB returns the total size of the local NVMe disks of
+// this VM size, e.g. on Lsv3 sizes.
+func (s *SKU) NvmeDiskSizeInMiB() (int64, error) {
+	return s.GetCapabilityIntegerQuantity(CapabilityNvmeDiskSizeInMiB)
+}
+
+// MaxWriteAcceleratorDisksAllowed returns the number of disks which may
+// enable write accelerator on this VM size, e.g. on M series sizes.
+func (s *SKU) MaxWriteAcceleratorDisksAllowed() (int64, error) {
+	return s.GetCapabilityIntegerQuantity(CapabilityMaxWriteAcceleratorDisksAllowed)
+}
+
+// IsWriteAcceleratorSupported returns true when at least one disk may
+// enable write accelerator on this VM size.
+func (s *SKU) IsWriteAcceleratorSupported() bool {
+	disks, err := s.MaxWriteAcceleratorDisksAllowed()
+	return err == nil && disks > 0
+}
+
+// DiskPerformance describes the IOPS and throughput limits of a disk
+// path of a VM size.
+type DiskPerformance struct {
+	IOPS     int64
+	ReadBps  int64
+	WriteBps int64
+}
+
+// CombinedTempDiskAndCached returns the combined performance limits of
+// the temporary disk and the cache of this VM size, from the
+// CombinedTempDiskAndCached capabilities. It errors if any is missing.
+func (s *SKU) CombinedTempDiskAndCached() (DiskPerformance, error) {
+	iops, err := s.GetCapabilityIntegerQuantity(CapabilityCombinedTempDiskAndCachedIOPS)
+	if err != nil {
+		return DiskPerformance{}, err
+	}
+	readBps, err := s.GetCapabilityIntegerQuantity(CapabilityCombinedTempDiskAndCachedReadBytesPerSecond)
+	if err != nil {
+		return DiskPerformance{}, err
+	}
+	writeBps, err := s.GetCapabilityIntegerQuantity(CapabilityCombinedTempDiskAndCachedWriteBytesPerSecond)
+	if err != nil {
+		return DiskPerformance{}, err
+	}
+	return DiskPerformance{IOPS: iops, ReadBps: readBps, WriteBps: writeBps}, nil
+}
+
 // IsEncryptionAtHostSupported returns true when Encryption at Host is
 // supported for the VM size.
 func (s *SKU) IsEncryptionAtHostSupported() bool {
 	return s.HasCapability(EncryptionAtHost)
 }
 
+// IsEncryptionAtHostSupportedStrict returns whether Encryption at Host
+// is supported for the VM size, and whether the sku reported it at all.
+// known is false when the capability is absent or its value is neither
+// "True" nor "False", so compliance policies can fail closed.
+func (s *SKU) IsEncryptionAtHostSupportedStrict() (supported, known bool) {
+	support := s.CapabilitySupport(EncryptionAtHost)
+	return support == CapabilitySupported, support != CapabilityUnknown
+}
+
 // From ultra SSD documentation
 //   https://docs.microsoft.com/en-us/azure/virtual-machines/disks-enable-ultra-ssd
 // Ultra SSD can be either supported on
@@ -96,6 +258,12 @@ func (s *SKU) IsUltraSSDAvailableInAvailabilityZone(zone string) bool {
 	return s.HasCapabilityInZone(UltraSSDAvailable, zone)
 }
 
+// UltraSSDAvailableZones returns the sorted zones of the location in
+// which the VM size advertises ultra SSD, or nil if none do.
+func (s *SKU) UltraSSDAvailableZones(location string) []string {
+	return s.ZonesWithCapability(location, UltraSSDAvailable)
+}
+
 // IsUltraSSDAvailable returns true when a VM size has ultra SSD enabled
 // in at least 1 unrestricted zone.
 //
@@ -121,16 +289,55 @@ func (s *SKU) IsPremiumIO() bool {
 	return s.HasCapability(CapabilityPremiumIO)
 }
 
+// IsSpotCapable returns true when the VM size can be requested as a
+// Spot or low priority virtual machine.
+func (s *SKU) IsSpotCapable() bool {
+	return s.HasCapability(CapabilityLowPriorityCapable)
+}
+
+// IsCapacityReservationSupported returns true when the VM size can be
+// reserved in a capacity reservation group.
+func (s *SKU) IsCapacityReservationSupported() bool {
+	return s.HasCapability(CapabilityCapacityReservationSupported)
+}
+
 // IsHyperVGen1Supported returns true when the VM size supports
-// accelerated networking.
+// hyper-v generation 1.
 func (s *SKU) IsHyperVGen1Supported() bool {
-	return s.HasCapabilityWithSeparator(HyperVGenerations, HyperVGeneration1)
+	return s.IsHyperVGenerationSupported(HyperVGenerationV1)
 }
 
 // IsHyperVGen2Supported returns true when the VM size supports
-// accelerated networking.
+// hyper-v generation 2.
 func (s *SKU) IsHyperVGen2Supported() bool {
-	return s.HasCapabilityWithSeparator(HyperVGenerations, HyperVGeneration2)
+	return s.IsHyperVGenerationSupported(HyperVGenerationV2)
+}
+
+// IsHyperVGenerationSupported returns true when the VM size supports
+// the provided hyper-v generation.
+func (s *SKU) IsHyperVGenerationSupported(generation HyperVGeneration) bool {
+	for _, candidate := range s.HyperVGenerations() {
+		if strings.EqualFold(string(candidate), string(generation)) {
+			return true
+		}
+	}
+	return false
+}
+
+// HyperVGenerations returns the hyper-v generations the VM size
+// supports, in the order listed by the API.
+func (s *SKU) HyperVGenerations() []HyperVGeneration {
+	value, err := s.GetCapabilityString(HyperVGenerations)
+	if err != nil {
+		return nil
+	}
+	var result []HyperVGeneration
+	for _, generation := range strings.Split(value, ",") {
+		if generation = strings.TrimSpace(generation); generation != "" {
+			result = append(result, HyperVGeneration(generation))
+		}
+	}
+	return result
 }
 
 // GetCPUArchitectureType returns cpu arch for the VM size.
@@ -139,6 +346,27 @@ func (s *SKU) GetCPUArchitectureType() (string, error) {
 	return s.GetCapabilityString(CapabilityCPUArchitectureType)
 }
 
+// CPUArchitecture returns the cpu architecture of the VM size.
+// It errors if value is nil or not found.
+func (s *SKU) CPUArchitecture() (Architecture, error) {
+	value, err := s.GetCPUArchitectureType()
+	if err != nil {
+		return "", err
+	}
+	for _, known := range []Architecture{ArchitectureX64, ArchitectureArm64} {
+		if strings.EqualFold(value, string(known)) {
+			return known, nil
+		}
+	}
+	return Architecture(value), nil
+}
+
+// IsArm64 returns true when the VM size has an Arm64 cpu.
+func (s *SKU) IsArm64() bool {
+	architecture, err := s.CPUArchitecture()
+	return err == nil && architecture == ArchitectureArm64
+}
+
 // GetCapabilityIntegerQuantity retrieves and parses the value of an
 // integer numeric capability with the provided name. It errors if the
 // capability is not found, the value was nil, or the value could not be
@@ -185,6 +413,68 @@ func (s *SKU) GetCapabilityFloatQuantity(name string) (float64, error) {
 	return -1, &ErrCapabilityNotFound{name}
 }
 
+// GetCapabilityIntegerQuantityOK is like GetCapabilityIntegerQuantity,
+// but reports a missing, nil or unparseable value with false instead of
+// allocating an error, for hot paths which only care about presence.
+func (s *SKU) GetCapabilityIntegerQuantityOK(name string) (int64, bool) {
+	value, ok := s.capabilityValue(name)
+	if !ok {
+		return -1, false
+	}
+	intVal, err := strconv.ParseInt(value, ten, sixtyFour)
+	if err != nil {
+		return -1, false
+	}
+	return intVal, true
+}
+
+// GetCapabilityFloatQuantityOK is like GetCapabilityFloatQuantity, but
+// reports a missing, nil or unparseable value with false.
+func (s *SKU) GetCapabilityFloatQuantityOK(name string) (float64, bool) {
+	value, ok := s.capabilityValue(name)
+	if !ok {
+		return -1, false
+	}
+	floatVal, err := strconv.ParseFloat(value, sixtyFour)
+	if err != nil {
+		return -1, false
+	}
+	return floatVal, true
+}
+
+// VCPUOK returns the number of vCPUs, and false if it is unknown.
+func (s *SKU) VCPUOK() (int64, bool) {
+	return s.GetCapabilityIntegerQuantityOK(VCPUs)
+}
+
+// GPUOK returns the number of GPUs, and false if it is unknown.
+func (s *SKU) GPUOK() (int64, bool) {
+	return s.GetCapabilityIntegerQuantityOK(GPUs)
+}
+
+// MemoryOK returns the amount of memory in GB, and false if it is
+// unknown.
+func (s *SKU) MemoryOK() (float64, bool) {
+	return s.GetCapabilityFloatQuantityOK(MemoryGB)
+}
+
+// capabilityValue returns the value of the first capability with the
+// name, matched exactly, and false if it is missing or nil.
+func (s *SKU) capabilityValue(name string) (string, bool) {
+	if s.Capabilities == nil {
+		return "", false
+	}
+	for _, capability := range *s.Capabilities {
+		if capability.Name != nil && *capability.Name == name {
+			if capability.Value == nil {
+				return "", false
+			}
+			return *capability.Value, true
+		}
+	}
+	return "", false
+}
+
 // GetCapabilityString retrieves string capability with the provided name.
 // It errors if the capability is not found or the value was nil
 func (s *SKU) GetCapabilityString(name string) (string, error) {
@@ -202,6 +492,31 @@ func (s *SKU) GetCapabilityString(name string) (string, error) {
 	return "", &ErrCapabilityNotFound{name}
 }
 
+// CapabilityMap returns the values of all capabilities by name, for
+// capabilities skewer does not wrap yet. Nil values map to the empty
+// string, and the first of duplicate names wins. Use
+// GetCapabilityString to read a single capability.
+func (s *SKU) CapabilityMap() map[string]string {
+	if s.Capabilities == nil {
+		return nil
+	}
+	result := make(map[string]string, len(*s.Capabilities))
+	for _, capability := range *s.Capabilities {
+		if capability.Name == nil {
+			continue
+		}
+		if _, ok := result[*capability.Name]; ok {
+			continue
+		}
+		value := ""
+		if capability.Value != nil {
+			value = *capability.Value
+		}
+		result[*capability.Name] = value
+	}
+	return result
+}
+
 // HasCapability return true for a capability which can be either
 // supported or not. Examples include "EphemeralOSDiskSupported",
 // "EncryptionAtHostSupported", "AcceleratedNetworkingEnabled", and
@@ -218,6 +533,72 @@ func (s *SKU) HasCapability(name string) bool {
 	return false
 }
 
+// CapabilitySupport returns whether a binary capability is supported,
+// unsupported, or unknown to the sku. Unlike HasCapability, it
+// distinguishes an absent capability from one reported as "False".
+func (s *SKU) CapabilitySupport(name string) Supported {
+	supported, _ := s.GetCapabilitySupported(name)
+	return supported
+}
+
+// GetCapabilitySupported returns whether a binary capability is
+// supported, unsupported, or unknown to the sku, matching the name
+// case-insensitively. When unknown, the error tells whether the
+// capability was not found, the value was nil, or the value was neither
+// "True" nor "False", so policy engines can treat each conservatively.
+func (s *SKU) GetCapabilitySupported(name string) (Supported, error) {
+	if s.Capabilities == nil {
+		return CapabilityUnknown, &ErrCapabilityNotFound{name}
+	}
+	for _, capability := range *s.Capabilities {
+		if capability.Name != nil && strings.EqualFold(*capability.Name, name) {
+			if capability.Value == nil {
+				return CapabilityUnknown, &ErrCapabilityValueNil{name}
+			}
+			switch {
+			case strings.EqualFold(*capability.Value, string(CapabilitySupported)):
+				return CapabilitySupported, nil
+			case strings.EqualFold(*capability.Value, string(CapabilityUnsupported)):
+				return CapabilityUnsupported, nil
+			}
+			return CapabilityUnknown, &ErrCapabilityValueParse{name, *capability.Value, fmt.Errorf("not a boolean value")}
+		}
+	}
+	return CapabilityUnknown, &ErrCapabilityNotFound{name}
+}
+
+// ZonesWithCapability returns the sorted zones of the location whose
+// zone details report the named capability as supported, or nil if none
+// do. Names match case-insensitively, as for HasCapabilityInZone.
+func (s *SKU) ZonesWithCapability(location, name string) []string {
+	if s.LocationInfo == nil {
+		return nil
+	}
+	zones := map[string]bool{}
+	for _, locationInfo := range *s.LocationInfo {
+		if locationInfo.Location == nil || !locationEquals(*locationInfo.Location, location) || locationInfo.ZoneDetails == nil {
+			continue
+		}
+		for _, zoneDetails := range *locationInfo.ZoneDetails {
+			if zoneDetails.Name == nil || zoneDetails.Capabilities == nil {
+				continue
+			}
+			for _, capability := range *zoneDetails.Capabilities {
+				if capability.Name != nil && strings.EqualFold(*capability.Name, name) &&
+					capability.Value != nil && strings.EqualFold(*capability.Value, string(CapabilitySupported)) {
+					for _, zone := range *zoneDetails.Name {
+						zones[zone] = true
+					}
+				}
+			}
+		}
+	}
+	if len(zones) == 0 {
+		return nil
+	}
+	return sortedZones(zones)
+}
+
 // HasZonalCapability return true for a capability which can be either
 // supported or not. Examples include "UltraSSDAvailable".
 // This function only checks that zone details suggest support: it will
@@ -314,24 +695,47 @@ func (s *SKU) HasCapabilityWithSeparator(name, value string) bool {
 // "CombinedTempDiskAndCachedWriteBytesPerSecond", "UncachedDiskIOPS",
 // and "UncachedDiskBytesPerSecond"
 func (s *SKU) HasCapabilityWithMinCapacity(name string, value int64) (bool, error) {
+	quantity, found, err := s.findIntegerCapability(name)
+	return found && quantity >= value, err
+}
+
+// HasCapabilityWithMaxCapacity returns true when the SKU has a
+// capability with the requested name, and the value is less than or
+// equal to the desired value. It applies to the same capabilities as
+// HasCapabilityWithMinCapacity.
+func (s *SKU) HasCapabilityWithMaxCapacity(name string, value int64) (bool, error) {
+	quantity, found, err := s.findIntegerCapability(name)
+	return found && quantity <= value, err
+}
+
+// CapabilityInRange returns true when the SKU has a capability with the
+// requested name, and the value is between min and max inclusive, e.g.
+// a temporary disk between 64 and 512 GiB, parsing the value once.
+func (s *SKU) CapabilityInRange(name string, min, max int64) (bool, error) {
+	quantity, found, err := s.findIntegerCapability(name)
+	return found && quantity >= min && quantity <= max, err
+}
+
+// findIntegerCapability returns the integer value of the capability
+// matching name case-insensitively. Missing names and nil values are
+// not found, without an error.
+func (s *SKU) findIntegerCapability(name string) (int64, bool, error) {
 	if s.Capabilities == nil {
-		return false, nil
+		return 0, false, nil
 	}
 	for _, capability := range *s.Capabilities {
 		if capability.Name != nil && strings.EqualFold(*capability.Name, name) {
-			if capability.Value != nil {
-				intVal, err := strconv.ParseInt(*capability.Value, ten, sixtyFour)
-				if err != nil {
-					return false, errors.Wrapf(err, "failed to parse string '%s' as int64", *capability.Value)
-				}
-				if intVal >= value {
-					return true, nil
-				}
+			if capability.Value == nil {
+				return 0, false, nil
+			}
+			intVal, err := strconv.ParseInt(*capability.Value, ten, sixtyFour)
+			if err != nil {
+				return 0, false, errors.Wrapf(err, "failed to parse string '%s' as int64", *capability.Value)
 			}
-			return false, nil
+			return intVal, true, nil
 		}
 	}
-	return false, nil
+	return 0, false, nil
 }
 
 // IsAvailable returns true when the requested location matches one on
@@ -343,21 +747,42 @@ func (s *SKU) IsAvailable(location string) bool {
 	for _, locationInfo := range *s.LocationInfo {
 		if locationInfo.Location != nil {
 			if locationEquals(*locationInfo.Location, location) {
-				if s.Restrictions != nil {
-					for _, restriction := range *s.Restrictions {
-						// Can't deploy to any zones in this location. We're done.
-						if restriction.Type == compute.Location {
-							return false
-						}
-					}
-				}
-				return true
+				// Can't deploy to any zones in this location. We're done.
+				return !s.HasLocationRestriction(location)
 			}
 		}
 	}
 	return false
 }
 
+// IsAvailableWithZones returns true when the sku is available in the
+// location and, if the location lists availability zones for the sku,
+// at least one of them is unrestricted.
+func (s *SKU) IsAvailableWithZones(location string) bool {
+	if !s.IsAvailable(location) {
+		return false
+	}
+	return len(s.locationZones(location)) == 0 || len(s.AvailabilityZones(location)) > 0
+}
+
+// locationZones returns all zones listed for the location, including
+// restricted ones.
+func (s *SKU) locationZones(location string) []string {
+	if s.LocationInfo == nil {
+		return nil
+	}
+	var result []string
+	for _, locationInfo := range *s.LocationInfo {
+		if locationInfo.Location == nil || locationInfo.Zones == nil {
+			continue
+		}
+		if locationEquals(*locationInfo.Location, location) {
+			result = append(result, *locationInfo.Zones...)
+		}
+	}
+	return result
+}
+
 // IsRestricted returns true when a location restriction exists for
 // this SKU.
 func (s *SKU) IsRestricted(location string) bool {
@@ -418,6 +843,12 @@ func (s *SKU) GetFamilyName() string {
 	return *s.Family
 }
 
+// IsPromo returns true for promotional skus, named with a "_Promo"
+// suffix, e.g. Standard_D13_v2_Promo.
+func (s *SKU) IsPromo() bool {
+	return strings.HasSuffix(strings.ToLower(s.GetName()), "_promo")
+}
+
 // GetSize returns the size of this resource sku. It normalizes pointers
 // to the empty string for comparison purposes. For example,
 // "M416ms_v2" for a virtual machine.
@@ -429,6 +860,37 @@ func (s *SKU) GetSize() string {
 	return *s.Size
 }
 
+// GetTier returns the tier of this resource sku. It normalizes pointers
+// to the empty string for comparison purposes. For example, "Standard"
+// for a virtual machine.
+func (s *SKU) GetTier() string {
+	if s.Tier == nil {
+		return ""
+	}
+
+	return *s.Tier
+}
+
+// Identifier holds the identifying fields of a resource sku, which ARM
+// payloads such as scale set skus require separately.
+type Identifier struct {
+	ResourceType string `json:"resourceType"`
+	Name         string `json:"name"`
+	Tier         string `json:"tier"`
+	Size         string `json:"size"`
+}
+
+// Identifier returns the identifying fields of this resource sku,
+// normalizing pointers to the empty string.
+func (s *SKU) Identifier() Identifier {
+	return Identifier{
+		ResourceType: s.GetResourceType(),
+		Name:         s.GetName(),
+		Tier:         s.GetTier(),
+		Size:         s.GetSize(),
+	}
+}
+
 func (s *SKU) GetVMSize() (*VMSizeType, error) {
 	return getVMSize(s.GetSize())
 }
@@ -489,23 +951,50 @@ func (s *SKU) HasLocationRestriction(location string) bool {
 	return false
 }
 
+// locationRestrictions returns all restrictions, of any type, which
+// apply to the given location.
+func (s *SKU) locationRestrictions(location string) []compute.ResourceSkuRestrictions {
+	if s.Restrictions == nil {
+		return nil
+	}
+
+	var result []compute.ResourceSkuRestrictions
+	for _, restriction := range *s.Restrictions {
+		if restriction.Values == nil {
+			continue
+		}
+		for _, candidate := range *restriction.Values {
+			if locationEquals(candidate, location) {
+				result = append(result, restriction)
+				break
+			}
+		}
+	}
+
+	return result
+}
+
 // IsConfidentialComputingTypeSNP return true if ConfidentialComputingType is SNP for this sku.
 func (s *SKU) IsConfidentialComputingTypeSNP() (bool, error) {
 	return s.HasCapabilityWithSeparator(CapabilityConfidentialComputingType, ConfidentialComputingTypeSNP), nil
 }
 
-// Official documentation for Trusted Launch states:
-// The response will be similar to the following form:
-// IsTrustedLaunchEnabled True in the output indicates that the Generation 2 VM size does not support Trusted launch.
+// IsTrustedLaunchEnabled returns true when Trusted Launch is supported
+// for this sku.
+//
+// Deprecated: use IsTrustedLaunchSupported.
+func (s *SKU) IsTrustedLaunchEnabled() (bool, error) {
+	return s.IsTrustedLaunchSupported(), nil
+}
+
+// IsTrustedLaunchSupported returns true when Trusted Launch can be used
+// with this sku. Official documentation for Trusted Launch states:
+// TrustedLaunchDisabled True in the output indicates that the Generation 2 VM size does not support Trusted launch.
 // If it's a Generation 2 VM size and TrustedLaunchDisabled is not part of the output,
 // it implies that Trusted launch is supported for that VM size.
-func (s *SKU) IsTrustedLaunchEnabled() (bool, error) {
-	if s.IsHyperVGen2Supported() {
-		if !s.HasCapabilityWithSeparator(CapabilityTrustedLaunchDisabled, string(CapabilitySupported)) {
-			return true, nil
-		}
-	}
-	return false, nil
+func (s *SKU) IsTrustedLaunchSupported() bool {
+	return s.IsHyperVGen2Supported() &&
+		!s.HasCapabilityWithSeparator(CapabilityTrustedLaunchDisabled, string(CapabilitySupported))
 }
 
 // AvailabilityZones returns the list of Availability Zones which have this resource SKU available and unrestricted.
@@ -562,13 +1051,171 @@ func (s *SKU) AvailabilityZones(location string) map[string]bool { //nolint:gocy
 	return availableZones
 }
 
+// AvailabilityZoneList returns the zones of AvailabilityZones as a
+// slice sorted numerically, or nil when the sku has no available zones
+// in the location.
+func (s *SKU) AvailabilityZoneList(location string) []string {
+	zones := s.AvailabilityZones(location)
+	if len(zones) == 0 {
+		return nil
+	}
+	return sortedZones(zones)
+}
+
+// ExtendedLocations returns the extended locations, e.g. edge zones,
+// listed for the location in API order, or nil if none are. They are
+// only returned by the API when listing with WithExtendedLocations.
+func (s *SKU) ExtendedLocations(location string) []string {
+	if s.LocationInfo == nil {
+		return nil
+	}
+	var result []string
+	for _, locationInfo := range *s.LocationInfo {
+		if locationInfo.Location == nil || locationInfo.ExtendedLocations == nil || !locationEquals(*locationInfo.Location, location) {
+			continue
+		}
+		result = append(result, *locationInfo.ExtendedLocations...)
+	}
+	return result
+}
+
+// IsAvailableInExtendedLocation returns true when the extended location
+// is listed under any location of the sku, matched case-insensitively,
+// and that location is not restricted.
+func (s *SKU) IsAvailableInExtendedLocation(name string) bool {
+	if s.LocationInfo == nil {
+		return false
+	}
+	for _, locationInfo := range *s.LocationInfo {
+		if locationInfo.Location == nil || locationInfo.ExtendedLocations == nil {
+			continue
+		}
+		for _, candidate := range *locationInfo.ExtendedLocations {
+			if strings.EqualFold(candidate, name) && !s.HasLocationRestriction(*locationInfo.Location) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IsAvailableInZone returns true when the zone is listed for the
+// location and neither the location nor the zone is restricted. It is
+// equivalent to AvailabilityZones(location)[zone] without allocating.
+func (s *SKU) IsAvailableInZone(location, zone string) bool {
+	if s.LocationInfo == nil {
+		return false
+	}
+
+	listed := false
+	for _, locationInfo := range *s.LocationInfo {
+		if locationInfo.Location == nil || locationInfo.Zones == nil || !locationEquals(*locationInfo.Location, location) {
+			continue
+		}
+		for _, candidate := range *locationInfo.Zones {
+			if candidate == zone {
+				listed = true
+				break
+			}
+		}
+	}
+	if !listed || s.Restrictions == nil {
+		return listed
+	}
+
+	for _, restriction := range *s.Restrictions {
+		if restriction.Values == nil || !containsLocation(*restriction.Values, location) {
+			continue
+		}
+		if restriction.Type == compute.Location {
+			return false
+		}
+		if restriction.RestrictionInfo != nil && restriction.RestrictionInfo.Zones != nil {
+			for _, candidate := range *restriction.RestrictionInfo.Zones {
+				if candidate == zone {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// containsLocation returns true when the location is among values.
+func containsLocation(values []string, location string) bool {
+	for _, candidate := range values {
+		if locationEquals(candidate, location) {
+			return true
+		}
+	}
+	return false
+}
+
 // Equal returns true when two skus have the same location, type, and name.
 func (s *SKU) Equal(other *SKU) bool {
-	location, localErr := s.GetLocation()
-	otherLocation, otherErr := s.GetLocation()
-	return strings.EqualFold(s.GetResourceType(), other.GetResourceType()) &&
-		strings.EqualFold(s.GetName(), other.GetName()) &&
-		locationEquals(location, otherLocation) &&
-		localErr != nil &&
-		otherErr != nil
+	return s.EqualWith(other, CompareResourceType(), CompareName(), CompareLocation())
+}
+
+// EqualByName returns true when two skus have the same name, e.g. to
+// match a size across locations and resource types.
+func (s *SKU) EqualByName(other *SKU) bool {
+	return s.EqualWith(other, CompareName())
+}
+
+// EqualByNameAndLocation returns true when two skus have the same name
+// and location.
+func (s *SKU) EqualByNameAndLocation(other *SKU) bool {
+	return s.EqualWith(other, CompareName(), CompareLocation())
+}
+
+// EqualOption selects a field compared by EqualWith.
+type EqualOption func(*equalConfig)
+
+type equalConfig struct {
+	resourceType bool
+	name         bool
+	location     bool
+}
+
+// CompareResourceType compares resource types case-insensitively.
+func CompareResourceType() EqualOption {
+	return func(c *equalConfig) { c.resourceType = true }
+}
+
+// CompareName compares names case-insensitively.
+func CompareName() EqualOption {
+	return func(c *equalConfig) { c.name = true }
+}
+
+// CompareLocation compares the first location of each sku, normalized.
+// Skus without locations are only equal to each other.
+func CompareLocation() EqualOption {
+	return func(c *equalConfig) { c.location = true }
+}
+
+// EqualWith returns true when two skus are equal in every field
+// selected by the options. Nil skus are only equal to each other.
+func (s *SKU) EqualWith(other *SKU, opts ...EqualOption) bool {
+	if s == nil || other == nil {
+		return s == other
+	}
+	config := &equalConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	if config.resourceType && !strings.EqualFold(s.GetResourceType(), other.GetResourceType()) {
+		return false
+	}
+	if config.name && !strings.EqualFold(s.GetName(), other.GetName()) {
+		return false
+	}
+	if config.location {
+		location, localErr := s.GetLocation()
+		otherLocation, otherErr := other.GetLocation()
+		if (localErr != nil) != (otherErr != nil) || !locationEquals(location, otherLocation) {
+			return false
+		}
+	}
+	return true
 }
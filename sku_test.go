@@ -152,6 +152,152 @@ func Test_SKU_HasCapability(t *testing.T) {
 	}
 }
 
+func Test_SKU_CapabilitySupport(t *testing.T) {
+	cases := map[string]struct {
+		sku       compute.ResourceSku
+		expect    Supported
+		expectErr error
+	}{
+		"absent capability should be unknown": {
+			sku:       compute.ResourceSku{},
+			expect:    CapabilityUnknown,
+			expectErr: &ErrCapabilityNotFound{},
+		},
+		"nil value should be unknown": {
+			sku: compute.ResourceSku{
+				Capabilities: &[]compute.ResourceSkuCapabilities{
+					{
+						Name: to.StringPtr("foo"),
+					},
+				},
+			},
+			expect:    CapabilityUnknown,
+			expectErr: &ErrCapabilityValueNil{},
+		},
+		"weird value should be unknown": {
+			sku: compute.ResourceSku{
+				Capabilities: &[]compute.ResourceSkuCapabilities{
+					{
+						Name:  to.StringPtr("foo"),
+						Value: to.StringPtr("foobar"),
+					},
+				},
+			},
+			expect:    CapabilityUnknown,
+			expectErr: &ErrCapabilityValueParse{},
+		},
+		"false value should be unsupported": {
+			sku: compute.ResourceSku{
+				Capabilities: &[]compute.ResourceSkuCapabilities{
+					{
+						Name:  to.StringPtr("foo"),
+						Value: to.StringPtr("False"),
+					},
+				},
+			},
+			expect: CapabilityUnsupported,
+		},
+		"true value should be supported": {
+			sku: compute.ResourceSku{
+				Capabilities: &[]compute.ResourceSkuCapabilities{
+					{
+						Name:  to.StringPtr("Foo"),
+						Value: to.StringPtr("true"),
+					},
+				},
+			},
+			expect: CapabilitySupported,
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			sku := SKU(tc.sku)
+			if diff := cmp.Diff(tc.expect, sku.CapabilitySupport("foo")); diff != "" {
+				t.Error(diff)
+			}
+			supported, err := sku.GetCapabilitySupported("foo")
+			if diff := cmp.Diff(tc.expect, supported); diff != "" {
+				t.Error(diff)
+			}
+			if diff := cmp.Diff(fmt.Sprintf("%T", tc.expectErr), fmt.Sprintf("%T", err)); diff != "" {
+				t.Errorf("unexpected error '%v': %s", err, diff)
+			}
+		})
+	}
+}
+
+func Test_SKU_IsEncryptionAtHostSupportedStrict(t *testing.T) {
+	cases := map[string]struct {
+		value         *string
+		absent        bool
+		expectSupport bool
+		expectKnown   bool
+	}{
+		"absent capability should be unknown": {
+			absent: true,
+		},
+		"weird value should be unknown": {
+			value: to.StringPtr("maybe"),
+		},
+		"false value should be known unsupported": {
+			value:       to.StringPtr("False"),
+			expectKnown: true,
+		},
+		"true value should be known supported": {
+			value:         to.StringPtr("True"),
+			expectSupport: true,
+			expectKnown:   true,
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			sku := SKU{}
+			if !tc.absent {
+				sku.Capabilities = &[]compute.ResourceSkuCapabilities{
+					{Name: to.StringPtr(EncryptionAtHost), Value: tc.value},
+				}
+			}
+			supported, known := sku.IsEncryptionAtHostSupportedStrict()
+			if supported != tc.expectSupport || known != tc.expectKnown {
+				t.Errorf("expected (%t, %t), got (%t, %t)", tc.expectSupport, tc.expectKnown, supported, known)
+			}
+		})
+	}
+}
+
+func Test_SKU_CapabilityMap(t *testing.T) {
+	cases := map[string]struct {
+		sku    SKU
+		expect map[string]string
+	}{
+		"nil capabilities should be nil": {},
+		"should map all capabilities": {
+			sku: SKU{
+				Capabilities: &[]compute.ResourceSkuCapabilities{
+					{Name: to.StringPtr(VCPUs), Value: to.StringPtr("4")},
+					{Name: to.StringPtr("foo")},
+					{Value: to.StringPtr("nameless")},
+					{Name: to.StringPtr(VCPUs), Value: to.StringPtr("8")},
+				},
+			},
+			expect: map[string]string{VCPUs: "4", "foo": ""},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.expect, tc.sku.CapabilityMap()); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
 func Test_SKU_HasCapabilityWithMinCapacity(t *testing.T) {
 	cases := map[string]struct {
 		sku        compute.ResourceSku
@@ -239,6 +385,84 @@ func Test_SKU_HasCapabilityWithMinCapacity(t *testing.T) {
 	}
 }
 
+func Test_SKU_CapabilityRanges(t *testing.T) {
+	sku := SKU{
+		Capabilities: &[]compute.ResourceSkuCapabilities{
+			{Name: to.StringPtr(MaxResourceVolumeMB), Value: to.StringPtr("131072")},
+			{Name: to.StringPtr("foo"), Value: to.StringPtr("bar")},
+			{Name: to.StringPtr("nil")},
+		},
+	}
+
+	cases := map[string]struct {
+		capability string
+		min, max   int64
+		expectMin  bool
+		expectMax  bool
+		expectIn   bool
+		expectErr  bool
+	}{
+		"value within range should match": {
+			capability: MaxResourceVolumeMB,
+			min:        65536,
+			max:        524288,
+			expectMin:  true,
+			expectMax:  true,
+			expectIn:   true,
+		},
+		"bounds should be inclusive": {
+			capability: "maxresourcevolumemb",
+			min:        131072,
+			max:        131072,
+			expectMin:  true,
+			expectMax:  true,
+			expectIn:   true,
+		},
+		"value below range should not match": {
+			capability: MaxResourceVolumeMB,
+			min:        262144,
+			max:        524288,
+			expectMax:  true,
+		},
+		"value above range should not match": {
+			capability: MaxResourceVolumeMB,
+			min:        1024,
+			max:        65536,
+			expectMin:  true,
+		},
+		"missing capability should not match": {
+			capability: "missing",
+			max:        65536,
+		},
+		"nil value should not match": {
+			capability: "nil",
+			max:        65536,
+		},
+		"invalid value should error": {
+			capability: "foo",
+			max:        65536,
+			expectErr:  true,
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			gotMin, errMin := sku.HasCapabilityWithMinCapacity(tc.capability, tc.min)
+			gotMax, errMax := sku.HasCapabilityWithMaxCapacity(tc.capability, tc.max)
+			gotIn, errIn := sku.CapabilityInRange(tc.capability, tc.min, tc.max)
+			if diff := cmp.Diff([]bool{tc.expectMin, tc.expectMax, tc.expectIn}, []bool{gotMin, gotMax, gotIn}); diff != "" {
+				t.Error(diff)
+			}
+			for _, err := range []error{errMin, errMax, errIn} {
+				if diff := cmp.Diff(tc.expectErr, err != nil); diff != "" {
+					t.Errorf("unexpected error '%v': %s", err, diff)
+				}
+			}
+		})
+	}
+}
+
 func Test_SKU_GetResourceTypeAndName(t *testing.T) {
 	cases := map[string]struct {
 		sku                compute.ResourceSku
@@ -282,6 +506,116 @@ func Test_SKU_GetResourceTypeAndName(t *testing.T) {
 	}
 }
 
+func Test_SKU_Identifier(t *testing.T) {
+	cases := map[string]struct {
+		sku    compute.ResourceSku
+		expect Identifier
+	}{
+		"nil fields should return empty strings": {},
+		"populated fields should return correctly": {
+			sku: compute.ResourceSku{
+				ResourceType: to.StringPtr(VirtualMachines),
+				Name:         to.StringPtr("Standard_D4s_v3"),
+				Tier:         to.StringPtr("Standard"),
+				Size:         to.StringPtr("D4s_v3"),
+			},
+			expect: Identifier{
+				ResourceType: VirtualMachines,
+				Name:         "Standard_D4s_v3",
+				Tier:         "Standard",
+				Size:         "D4s_v3",
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			sku := SKU(tc.sku)
+			if diff := cmp.Diff(tc.expect, sku.Identifier()); diff != "" {
+				t.Error(diff)
+			}
+			if diff := cmp.Diff(tc.expect.Tier, sku.GetTier()); diff != "" {
+				t.Errorf("mismatched tier\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_SKU_Equal(t *testing.T) {
+	sku := func(resourceType, name string, locations ...string) *SKU {
+		result := &SKU{ResourceType: to.StringPtr(resourceType), Name: to.StringPtr(name)}
+		if locations != nil {
+			result.Locations = &locations
+		}
+		return result
+	}
+
+	cases := map[string]struct {
+		a, b                *SKU
+		expect              bool
+		expectByName        bool
+		expectByNameAndLoc  bool
+		expectOnlyResources bool
+	}{
+		"identical skus should be equal": {
+			a:                   sku(VirtualMachines, "Standard_D2_v2", "eastus"),
+			b:                   sku("VIRTUALMACHINES", "standard_d2_v2", "East US"),
+			expect:              true,
+			expectByName:        true,
+			expectByNameAndLoc:  true,
+			expectOnlyResources: true,
+		},
+		"different locations should only be equal by name": {
+			a:                   sku(VirtualMachines, "Standard_D2_v2", "eastus"),
+			b:                   sku(VirtualMachines, "Standard_D2_v2", "westus"),
+			expectByName:        true,
+			expectOnlyResources: true,
+		},
+		"missing location should not equal a located sku": {
+			a:                   sku(VirtualMachines, "Standard_D2_v2", "eastus"),
+			b:                   sku(VirtualMachines, "Standard_D2_v2"),
+			expectByName:        true,
+			expectOnlyResources: true,
+		},
+		"different resource types should not be equal": {
+			a:                  sku(VirtualMachines, "Standard_D2_v2", "eastus"),
+			b:                  sku(Disks, "Standard_D2_v2", "eastus"),
+			expectByName:       true,
+			expectByNameAndLoc: true,
+		},
+		"different names should not be equal": {
+			a:                   sku(VirtualMachines, "Standard_D2_v2", "eastus"),
+			b:                   sku(VirtualMachines, "Standard_D4_v2", "eastus"),
+			expectOnlyResources: true,
+		},
+		"nil should only equal nil": {
+			a: sku(VirtualMachines, "Standard_D2_v2", "eastus"),
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			got := []bool{
+				tc.a.Equal(tc.b),
+				tc.a.EqualByName(tc.b),
+				tc.a.EqualByNameAndLocation(tc.b),
+				tc.a.EqualWith(tc.b, CompareResourceType()),
+			}
+			expect := []bool{tc.expect, tc.expectByName, tc.expectByNameAndLoc, tc.expectOnlyResources}
+			if diff := cmp.Diff(expect, got); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+
+	var a, b *SKU
+	if !a.Equal(b) {
+		t.Error("expected nil skus to be equal")
+	}
+}
+
 func Test_SKU_IsResourceType(t *testing.T) {
 	cases := map[string]struct {
 		sku          compute.ResourceSku
@@ -394,7 +728,85 @@ func Test_SKU_GetLocation(t *testing.T) {
 	}
 }
 
-func Test_SKU_AvailabilityZones(t *testing.T) {}
+func Test_SKU_AvailabilityZones(t *testing.T) {
+	cases := map[string]struct {
+		sku    SKU
+		expect []string
+	}{
+		"nil location info should have no zones": {
+			sku: SKU{Restrictions: &[]compute.ResourceSkuRestrictions{{Type: compute.Zone}}},
+		},
+		"nil fields should have no zones": {
+			sku: SKU{
+				LocationInfo: &[]compute.ResourceSkuLocationInfo{{}, {Location: to.StringPtr("eastus")}},
+				Restrictions: &[]compute.ResourceSkuRestrictions{{}, {Values: &[]string{"eastus"}}},
+			},
+		},
+		"should sort zones without restricted ones": {
+			sku: SKU{
+				LocationInfo: &[]compute.ResourceSkuLocationInfo{
+					{Location: to.StringPtr("eastus"), Zones: &[]string{"3", "10", "1", "2"}},
+				},
+				Restrictions: &[]compute.ResourceSkuRestrictions{
+					{
+						Type:            compute.Zone,
+						Values:          &[]string{"eastus"},
+						RestrictionInfo: &compute.ResourceSkuRestrictionInfo{Zones: &[]string{"2"}},
+					},
+				},
+			},
+			expect: []string{"1", "3", "10"},
+		},
+		"location restriction should have no zones": {
+			sku: SKU{
+				LocationInfo: &[]compute.ResourceSkuLocationInfo{
+					{Location: to.StringPtr("eastus"), Zones: &[]string{"1"}},
+				},
+				Restrictions: &[]compute.ResourceSkuRestrictions{
+					{Type: compute.Location, Values: &[]string{"eastus"}},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.expect, tc.sku.AvailabilityZoneList("eastus")); diff != "" {
+				t.Error(diff)
+			}
+			if diff := cmp.Diff(len(tc.expect), len(tc.sku.AvailabilityZones("eastus"))); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func Test_SKU_IsAvailableInZone(t *testing.T) {
+	dataWrapper, err := newDataWrapper("./testdata/eastus.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, sku := range Wrap(dataWrapper.Value) {
+		sku := sku
+		for _, location := range []string{"eastus", "EastUS", "westus"} {
+			zones := sku.AvailabilityZones(location)
+			for _, zone := range []string{"1", "2", "3", "4"} {
+				if diff := cmp.Diff(zones[zone], sku.IsAvailableInZone(location, zone)); diff != "" {
+					t.Errorf("%s in %s zone %s: %s", sku.GetName(), location, zone, diff)
+				}
+			}
+		}
+		if allocs := testing.AllocsPerRun(10, func() { sku.IsAvailableInZone("eastus", "1") }); allocs != 0 {
+			t.Errorf("expected no allocations, got %f", allocs)
+		}
+	}
+
+	if (&SKU{}).IsAvailableInZone("eastus", "1") {
+		t.Error("expected sku without location info to be unavailable")
+	}
+}
 
 //nolint:funlen
 func Test_SKU_HasCapabilityInZone(t *testing.T) {
@@ -517,3 +929,507 @@ func Test_SKU_HasCapabilityInZone(t *testing.T) {
 		})
 	}
 }
+
+func Test_SKU_HyperVGenerations(t *testing.T) {
+	cases := map[string]struct {
+		sku      compute.ResourceSku
+		expect   []HyperVGeneration
+		expectV1 bool
+		expectV2 bool
+	}{
+		"should return nothing without capability": {
+			sku: compute.ResourceSku{},
+		},
+		"should parse single generation": {
+			sku: compute.ResourceSku{
+				Capabilities: &[]compute.ResourceSkuCapabilities{
+					{
+						Name:  to.StringPtr(HyperVGenerations),
+						Value: to.StringPtr("V1"),
+					},
+				},
+			},
+			expect:   []HyperVGeneration{HyperVGenerationV1},
+			expectV1: true,
+		},
+		"should parse multiple generations": {
+			sku: compute.ResourceSku{
+				Capabilities: &[]compute.ResourceSkuCapabilities{
+					{
+						Name:  to.StringPtr(HyperVGenerations),
+						Value: to.StringPtr("V1, V2"),
+					},
+				},
+			},
+			expect:   []HyperVGeneration{HyperVGenerationV1, HyperVGenerationV2},
+			expectV1: true,
+			expectV2: true,
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			sku := SKU(tc.sku)
+			if diff := cmp.Diff(tc.expect, sku.HyperVGenerations()); diff != "" {
+				t.Error(diff)
+			}
+			if diff := cmp.Diff(tc.expectV1, sku.IsHyperVGen1Supported()); diff != "" {
+				t.Error(diff)
+			}
+			if diff := cmp.Diff(tc.expectV2, HyperVGenerationFilter(HyperVGenerationV2)(&sku)); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func Test_SKU_MemoryMiB(t *testing.T) {
+	cases := map[string]struct {
+		value  *string
+		expect int64
+		err    bool
+	}{
+		"should error without capability": {
+			err: true,
+		},
+		"should convert whole values": {
+			value:  to.StringPtr("16"),
+			expect: 16384,
+		},
+		"should convert fractional values exactly": {
+			value:  to.StringPtr("3.5"),
+			expect: 3584,
+		},
+		"should convert small fractional values exactly": {
+			value:  to.StringPtr("0.75"),
+			expect: 768,
+		},
+		"should error on invalid values": {
+			value: to.StringPtr("lots"),
+			err:   true,
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			sku := SKU{}
+			if tc.value != nil {
+				sku.Capabilities = &[]compute.ResourceSkuCapabilities{
+					{Name: to.StringPtr(MemoryGB), Value: tc.value},
+				}
+			}
+			got, err := sku.MemoryMiB()
+			if tc.err != (err != nil) {
+				t.Fatalf("expected error %t, got '%v'", tc.err, err)
+			}
+			if err == nil {
+				if diff := cmp.Diff(tc.expect, got); diff != "" {
+					t.Error(diff)
+				}
+			}
+			bytes, err := sku.MemoryBytes()
+			if tc.err != (err != nil) {
+				t.Fatalf("expected error %t, got '%v'", tc.err, err)
+			}
+			if err == nil {
+				if diff := cmp.Diff(tc.expect*bytesPerMiB, bytes); diff != "" {
+					t.Error(diff)
+				}
+			}
+		})
+	}
+}
+
+func Test_SKU_DiskUnits(t *testing.T) {
+	sku := SKU{
+		Capabilities: &[]compute.ResourceSkuCapabilities{
+			{Name: to.StringPtr(MaxResourceVolumeMB), Value: to.StringPtr("32768")},
+			{Name: to.StringPtr(CachedDiskBytes), Value: to.StringPtr("53687091200")},
+		},
+	}
+	if bytes, err := sku.TempDiskBytes(); bytes != 34359738368 || err != nil {
+		t.Errorf("expected 32GiB temporary disk, got value '%d' and error '%v'", bytes, err)
+	}
+	if gib, err := sku.MaxCachedDiskGiB(); gib != 50 || err != nil {
+		t.Errorf("expected 50GiB cache, got value '%f' and error '%v'", gib, err)
+	}
+
+	empty := SKU{}
+	if _, err := empty.TempDiskBytes(); err == nil {
+		t.Error("expected error without temporary disk capability")
+	}
+	if _, err := empty.MaxCachedDiskGiB(); err == nil {
+		t.Error("expected error without cache capability")
+	}
+}
+
+func Test_SKU_CPUArchitecture(t *testing.T) {
+	cases := map[string]struct {
+		value     *string
+		expect    Architecture
+		expectArm bool
+		err       bool
+	}{
+		"should error without capability": {
+			err: true,
+		},
+		"should normalize x64": {
+			value:  to.StringPtr("X64"),
+			expect: ArchitectureX64,
+		},
+		"should detect arm64": {
+			value:     to.StringPtr("Arm64"),
+			expect:    ArchitectureArm64,
+			expectArm: true,
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			sku := SKU{}
+			if tc.value != nil {
+				sku.Capabilities = &[]compute.ResourceSkuCapabilities{
+					{Name: to.StringPtr(CapabilityCPUArchitectureType), Value: tc.value},
+				}
+			}
+			got, err := sku.CPUArchitecture()
+			if tc.err != (err != nil) {
+				t.Fatalf("expected error %t, got '%v'", tc.err, err)
+			}
+			if diff := cmp.Diff(tc.expect, got); diff != "" {
+				t.Error(diff)
+			}
+			if diff := cmp.Diff(tc.expectArm, sku.IsArm64()); diff != "" {
+				t.Error(diff)
+			}
+			if diff := cmp.Diff(tc.expectArm, ArchitectureFilter(ArchitectureArm64)(&sku)); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func Test_SKU_IsTrustedLaunchSupported(t *testing.T) {
+	cases := map[string]struct {
+		capabilities []compute.ResourceSkuCapabilities
+		expect       bool
+	}{
+		"gen1 only should not be supported": {
+			capabilities: []compute.ResourceSkuCapabilities{
+				{Name: to.StringPtr(HyperVGenerations), Value: to.StringPtr("V1")},
+			},
+		},
+		"gen2 should be supported": {
+			capabilities: []compute.ResourceSkuCapabilities{
+				{Name: to.StringPtr(HyperVGenerations), Value: to.StringPtr("V1,V2")},
+			},
+			expect: true,
+		},
+		"gen2 with trusted launch disabled should not be supported": {
+			capabilities: []compute.ResourceSkuCapabilities{
+				{Name: to.StringPtr(HyperVGenerations), Value: to.StringPtr("V2")},
+				{Name: to.StringPtr(CapabilityTrustedLaunchDisabled), Value: to.StringPtr("True")},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			sku := SKU{Capabilities: &tc.capabilities}
+			if diff := cmp.Diff(tc.expect, sku.IsTrustedLaunchSupported()); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func Test_SKU_IsCapacityReservationSupported(t *testing.T) {
+	cases := map[string]struct {
+		capabilities []compute.ResourceSkuCapabilities
+		expect       bool
+	}{
+		"absent capability should not be supported": {},
+		"false value should not be supported": {
+			capabilities: []compute.ResourceSkuCapabilities{
+				{Name: to.StringPtr(CapabilityCapacityReservationSupported), Value: to.StringPtr("False")},
+			},
+		},
+		"true value should be supported": {
+			capabilities: []compute.ResourceSkuCapabilities{
+				{Name: to.StringPtr(CapabilityCapacityReservationSupported), Value: to.StringPtr("True")},
+			},
+			expect: true,
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			sku := SKU{Capabilities: &tc.capabilities}
+			if diff := cmp.Diff(tc.expect, sku.IsCapacityReservationSupported()); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func Test_SKU_NVMe(t *testing.T) {
+	cases := map[string]struct {
+		capabilities    []compute.ResourceSkuCapabilities
+		expectSupported bool
+		expectSize      int64
+		expectErr       bool
+	}{
+		"should not support nvme without capabilities": {
+			expectErr: true,
+		},
+		"should support nvme controller without local disks": {
+			capabilities: []compute.ResourceSkuCapabilities{
+				{Name: to.StringPtr(CapabilityDiskControllerTypes), Value: to.StringPtr("SCSI, NVMe")},
+			},
+			expectSupported: true,
+			expectErr:       true,
+		},
+		"should report local nvme disks": {
+			capabilities: []compute.ResourceSkuCapabilities{
+				{Name: to.StringPtr(CapabilityDiskControllerTypes), Value: to.StringPtr("SCSI")},
+				{Name: to.StringPtr(CapabilityNvmeDiskSizeInMiB), Value: to.StringPtr("1831420")},
+			},
+			expectSize: 1831420,
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			sku := SKU{Capabilities: &tc.capabilities}
+			if diff := cmp.Diff(tc.expectSupported, sku.IsNVMeSupported()); diff != "" {
+				t.Error(diff)
+			}
+			size, err := sku.NvmeDiskSizeInMiB()
+			if diff := cmp.Diff(tc.expectErr, err != nil); diff != "" {
+				t.Errorf("unexpected error '%v': %s", err, diff)
+			}
+			if err == nil {
+				if diff := cmp.Diff(tc.expectSize, size); diff != "" {
+					t.Error(diff)
+				}
+			}
+		})
+	}
+}
+
+func Test_SKU_WriteAccelerator(t *testing.T) {
+	cases := map[string]struct {
+		capabilities    []compute.ResourceSkuCapabilities
+		expectSupported bool
+		expectDisks     int64
+		expectErr       bool
+	}{
+		"should not support write accelerator without capability": {
+			expectErr: true,
+		},
+		"should not support write accelerator without disks": {
+			capabilities: []compute.ResourceSkuCapabilities{
+				{Name: to.StringPtr(CapabilityMaxWriteAcceleratorDisksAllowed), Value: to.StringPtr("0")},
+			},
+		},
+		"should report write accelerator disks": {
+			capabilities: []compute.ResourceSkuCapabilities{
+				{Name: to.StringPtr(CapabilityMaxWriteAcceleratorDisksAllowed), Value: to.StringPtr("16")},
+			},
+			expectSupported: true,
+			expectDisks:     16,
+		},
+		"should not support write accelerator with invalid value": {
+			capabilities: []compute.ResourceSkuCapabilities{
+				{Name: to.StringPtr(CapabilityMaxWriteAcceleratorDisksAllowed), Value: to.StringPtr("many")},
+			},
+			expectErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			sku := SKU{Capabilities: &tc.capabilities}
+			if diff := cmp.Diff(tc.expectSupported, sku.IsWriteAcceleratorSupported()); diff != "" {
+				t.Error(diff)
+			}
+			disks, err := sku.MaxWriteAcceleratorDisksAllowed()
+			if diff := cmp.Diff(tc.expectErr, err != nil); diff != "" {
+				t.Errorf("unexpected error '%v': %s", err, diff)
+			}
+			if err == nil {
+				if diff := cmp.Diff(tc.expectDisks, disks); diff != "" {
+					t.Error(diff)
+				}
+			}
+		})
+	}
+}
+
+func Test_SKU_IsConstrainedCoreSKU(t *testing.T) {
+	cases := map[string]struct {
+		sku    SKU
+		expect bool
+	}{
+		"should detect constrained capabilities": {
+			sku: SKU{
+				Size: to.StringPtr("E8-4s_v3"),
+				Capabilities: &[]compute.ResourceSkuCapabilities{
+					{Name: to.StringPtr(VCPUs), Value: to.StringPtr("8")},
+					{Name: to.StringPtr(CapabilityVCPUsAvailable), Value: to.StringPtr("4")},
+				},
+			},
+			expect: true,
+		},
+		"should not detect unconstrained capabilities": {
+			sku: SKU{
+				Size: to.StringPtr("E8s_v3"),
+				Capabilities: &[]compute.ResourceSkuCapabilities{
+					{Name: to.StringPtr(VCPUs), Value: to.StringPtr("8")},
+					{Name: to.StringPtr(CapabilityVCPUsAvailable), Value: to.StringPtr("8")},
+				},
+			},
+		},
+		"should fall back to size name": {
+			sku:    SKU{Size: to.StringPtr("E8-4s_v3")},
+			expect: true,
+		},
+		"should not detect unconstrained size name": {
+			sku: SKU{Size: to.StringPtr("E8s_v3")},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.expect, tc.sku.IsConstrainedCoreSKU()); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func Test_SKU_UltraSSDAvailableZones(t *testing.T) {
+	sku := &SKU{
+		LocationInfo: &[]compute.ResourceSkuLocationInfo{
+			{
+				Location: to.StringPtr("eastus"),
+				Zones:    &[]string{"1", "2", "3"},
+				ZoneDetails: &[]compute.ResourceSkuZoneDetails{
+					{
+						Name: &[]string{"3", "1"},
+						Capabilities: &[]compute.ResourceSkuCapabilities{
+							{Name: to.StringPtr(UltraSSDAvailable), Value: to.StringPtr("True")},
+						},
+					},
+					{
+						Name: &[]string{"2"},
+						Capabilities: &[]compute.ResourceSkuCapabilities{
+							{Name: to.StringPtr(UltraSSDAvailable), Value: to.StringPtr("False")},
+						},
+					},
+				},
+			},
+			{
+				Location: to.StringPtr("westus"),
+				ZoneDetails: &[]compute.ResourceSkuZoneDetails{
+					{
+						Name: &[]string{"2"},
+						Capabilities: &[]compute.ResourceSkuCapabilities{
+							{Name: to.StringPtr(UltraSSDAvailable), Value: to.StringPtr("True")},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if diff := cmp.Diff([]string{"1", "3"}, sku.UltraSSDAvailableZones("East US")); diff != "" {
+		t.Error(diff)
+	}
+	if diff := cmp.Diff([]string{"2"}, sku.UltraSSDAvailableZones("westus")); diff != "" {
+		t.Error(diff)
+	}
+	if zones := sku.UltraSSDAvailableZones("centralus"); zones != nil {
+		t.Errorf("expected no zones, got %v", zones)
+	}
+	if zones := (&SKU{}).UltraSSDAvailableZones("eastus"); zones != nil {
+		t.Errorf("expected no zones, got %v", zones)
+	}
+}
+
+func Test_SKU_ExtendedLocations(t *testing.T) {
+	sku := &SKU{
+		LocationInfo: &[]compute.ResourceSkuLocationInfo{
+			{Location: to.StringPtr("westus"), ExtendedLocations: &[]string{"losangeles"}},
+			{Location: to.StringPtr("eastus"), ExtendedLocations: &[]string{"atlanta"}},
+		},
+		Restrictions: &[]compute.ResourceSkuRestrictions{
+			{Type: compute.Location, Values: &[]string{"eastus"}, ReasonCode: compute.NotAvailableForSubscription},
+		},
+	}
+
+	if diff := cmp.Diff([]string{"losangeles"}, sku.ExtendedLocations("West US")); diff != "" {
+		t.Error(diff)
+	}
+	if got := sku.ExtendedLocations("centralus"); got != nil {
+		t.Errorf("expected no extended locations, got %v", got)
+	}
+	if !sku.IsAvailableInExtendedLocation("LosAngeles") {
+		t.Error("expected sku to be available in losangeles")
+	}
+	if sku.IsAvailableInExtendedLocation("atlanta") {
+		t.Error("expected sku to be unavailable in atlanta of restricted eastus")
+	}
+	if sku.IsAvailableInExtendedLocation("dallas") {
+		t.Error("expected sku to be unavailable in unlisted dallas")
+	}
+	if (&SKU{}).IsAvailableInExtendedLocation("losangeles") {
+		t.Error("expected sku without location info to be unavailable")
+	}
+}
+
+func Test_SKU_OKAccessors(t *testing.T) {
+	sku := &SKU{
+		Capabilities: &[]compute.ResourceSkuCapabilities{
+			{Name: to.StringPtr(VCPUs), Value: to.StringPtr("4")},
+			{Name: to.StringPtr(MemoryGB), Value: to.StringPtr("3.5")},
+			{Name: to.StringPtr(GPUs), Value: to.StringPtr("many")},
+			{Name: to.StringPtr(CapabilityACUs)},
+		},
+	}
+
+	if vcpus, ok := sku.VCPUOK(); !ok || vcpus != 4 {
+		t.Errorf("expected (4, true), got (%d, %t)", vcpus, ok)
+	}
+	if memory, ok := sku.MemoryOK(); !ok || memory != 3.5 {
+		t.Errorf("expected (3.5, true), got (%f, %t)", memory, ok)
+	}
+	if _, ok := sku.GPUOK(); ok {
+		t.Error("expected unparseable gpus to be unknown")
+	}
+	if _, ok := sku.GetCapabilityIntegerQuantityOK(CapabilityACUs); ok {
+		t.Error("expected nil acus to be unknown")
+	}
+	if _, ok := sku.GetCapabilityFloatQuantityOK("missing"); ok {
+		t.Error("expected missing capability to be unknown")
+	}
+	if _, ok := (&SKU{}).VCPUOK(); ok {
+		t.Error("expected sku without capabilities to be unknown")
+	}
+
+	if allocs := testing.AllocsPerRun(10, func() {
+		sku.VCPUOK()
+		sku.MemoryOK()
+		sku.GetCapabilityIntegerQuantityOK("missing")
+	}); allocs != 0 {
+		t.Errorf("expected no allocations, got %f", allocs)
+	}
+}
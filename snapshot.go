@@ -0,0 +1,23 @@
+package skewer
+
+import (
+	"context"
+	"strings"
+)
+
+// IsZoneRedundantStorage returns true for disk and snapshot skus which
+// replicate synchronously across zones, e.g. "Standard_ZRS".
+func (s *SKU) IsZoneRedundantStorage() bool {
+	if !s.IsResourceType(Disks) && !s.IsResourceType(Snapshots) {
+		return false
+	}
+	return strings.HasSuffix(strings.ToLower(s.GetName()), "_zrs")
+}
+
+// GetSnapshots returns the list of all snapshot skus, e.g.
+// "Standard_LRS" and "Premium_LRS", in a given azure location. Their
+// tier is reported by GetTier, and their availability by IsAvailable
+// and AvailabilityZones as for virtual machines.
+func (c *Cache) GetSnapshots(ctx context.Context) []SKU {
+	return c.ofType(ctx, Snapshots)
+}
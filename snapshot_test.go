@@ -0,0 +1,65 @@
+package skewer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-03-01/compute" //nolint:staticcheck
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_Snapshots(t *testing.T) {
+	snapshot := func(name, tier string) compute.ResourceSku {
+		return compute.ResourceSku{
+			ResourceType: to.StringPtr(Snapshots),
+			Name:         to.StringPtr(name),
+			Tier:         to.StringPtr(tier),
+			Locations:    &[]string{"eastus"},
+			LocationInfo: &[]compute.ResourceSkuLocationInfo{{Location: to.StringPtr("eastus")}},
+		}
+	}
+	skus := []compute.ResourceSku{
+		snapshot("Standard_LRS", "Standard"),
+		snapshot("Premium_LRS", "Premium"),
+		snapshot("Standard_ZRS", "Standard"),
+		{ResourceType: to.StringPtr(Disks), Name: to.StringPtr("Premium_ZRS"), Locations: &[]string{"eastus"}},
+	}
+
+	cache, err := NewStaticCache(Wrap(skus), WithLocation("eastus"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names, tiers []string
+	var zoneRedundant []string
+	for _, sku := range cache.GetSnapshots(context.Background()) {
+		sku := sku
+		if !sku.IsAvailable("eastus") {
+			t.Errorf("expected snapshot %s to be available", sku.GetName())
+		}
+		names = append(names, sku.GetName())
+		tiers = append(tiers, sku.GetTier())
+		if sku.IsZoneRedundantStorage() {
+			zoneRedundant = append(zoneRedundant, sku.GetName())
+		}
+	}
+	if diff := cmp.Diff([]string{"Premium_LRS", "Standard_LRS", "Standard_ZRS"}, names); diff != "" {
+		t.Error(diff)
+	}
+	if diff := cmp.Diff([]string{"Premium", "Standard", "Standard"}, tiers); diff != "" {
+		t.Error(diff)
+	}
+	if diff := cmp.Diff([]string{"Standard_ZRS"}, zoneRedundant); diff != "" {
+		t.Error(diff)
+	}
+
+	disk := SKU(skus[3])
+	if !disk.IsZoneRedundantStorage() {
+		t.Error("expected zone redundant disk sku")
+	}
+	vm := SKU{ResourceType: to.StringPtr(VirtualMachines), Name: to.StringPtr("Standard_ZRS")}
+	if vm.IsZoneRedundantStorage() {
+		t.Error("expected virtual machine sku not to be zone redundant storage")
+	}
+}
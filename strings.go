@@ -1,8 +1,11 @@
 package skewer
 
 import (
+	"sort"
+	"strconv"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 )
 
 func normalizeLocation(input string) string {
@@ -15,6 +18,77 @@ func normalizeLocation(input string) string {
 	return strings.ToLower(output)
 }
 
+// locationEquals compares locations like normalizeLocation without
+// allocating, since it runs for every sku of most queries.
 func locationEquals(a, b string) bool {
-	return normalizeLocation(a) == normalizeLocation(b)
+	for {
+		a = strings.TrimLeftFunc(a, unicode.IsSpace)
+		b = strings.TrimLeftFunc(b, unicode.IsSpace)
+		if a == "" || b == "" {
+			return a == b
+		}
+		runeA, sizeA := utf8.DecodeRuneInString(a)
+		runeB, sizeB := utf8.DecodeRuneInString(b)
+		if unicode.ToLower(runeA) != unicode.ToLower(runeB) {
+			return false
+		}
+		a, b = a[sizeA:], b[sizeB:]
+	}
+}
+
+// normalizeLocationKeys returns a copy of the map with normalized
+// location keys.
+func normalizeLocationKeys[V any](in map[string]V) map[string]V {
+	out := make(map[string]V, len(in))
+	for key, value := range in {
+		out[normalizeLocation(key)] = value
+	}
+	return out
+}
+
+// sortedZones returns the zones of the set in ascending order, comparing
+// numeric zone names by value.
+func sortedZones(zones map[string]bool) []string {
+	result := make([]string, 0, len(zones))
+	for zone := range zones {
+		result = append(result, zone)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, errA := strconv.Atoi(result[i])
+		b, errB := strconv.Atoi(result[j])
+		if errA == nil && errB == nil {
+			return a < b
+		}
+		return result[i] < result[j]
+	})
+	return result
+}
+
+// sortedKeys returns the keys of set sorted case-insensitively.
+func sortedKeys(set map[string]bool) []string {
+	if len(set) == 0 {
+		return nil
+	}
+	result := make([]string, 0, len(set))
+	for key := range set {
+		result = append(result, key)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return strings.ToLower(result[i]) < strings.ToLower(result[j])
+	})
+	return result
+}
+
+// stringSlicesEqual returns true when both slices hold the same strings
+// in the same order.
+func stringSlicesEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
 }
@@ -0,0 +1,148 @@
+package skewer
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// ErrSubscriptionNotFound will be returned when querying a subscription
+// a SubscriptionCache was not created with.
+type ErrSubscriptionNotFound struct {
+	Subscription string
+}
+
+func (e *ErrSubscriptionNotFound) Error() string {
+	return fmt.Sprintf("no cache for subscription %s", e.Subscription)
+}
+
+// SubscriptionCache holds a cache of skus per subscription, since
+// restrictions differ between subscriptions, for control planes
+// answering availability for the subscriptions of several customers.
+type SubscriptionCache struct {
+	caches map[string]*Cache
+}
+
+// NewSubscriptionCache instantiates a cache per subscription, keyed by
+// subscription id, each backed by the client of its subscription and
+// configured with the options, which must not provide a client. The
+// caches are populated concurrently, and the first failure is returned.
+func NewSubscriptionCache(ctx context.Context, clients map[string]ResourceClient, opts ...Option) (*SubscriptionCache, error) {
+	ids := make([]string, 0, len(clients))
+	for id := range clients {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	caches := make([]*Cache, len(ids))
+	errs := make([]error, len(ids))
+	var wg sync.WaitGroup
+	for i := range ids {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			options := append([]Option{WithResourceClient(clients[ids[i]])}, opts...)
+			caches[i], errs[i] = NewCache(ctx, options...)
+		}(i)
+	}
+	wg.Wait()
+
+	s := &SubscriptionCache{caches: make(map[string]*Cache, len(ids))}
+	for i, id := range ids {
+		if errs[i] != nil {
+			return nil, errors.Wrapf(errs[i], "failed to create cache for subscription %s", id)
+		}
+		s.caches[subscriptionKey(id)] = caches[i]
+	}
+	return s, nil
+}
+
+// ForSubscription returns the cache of the subscription. Ids compare
+// case insensitively, like subscription ids in ARM.
+func (s *SubscriptionCache) ForSubscription(id string) (*Cache, error) {
+	cache, ok := s.caches[subscriptionKey(id)]
+	if !ok {
+		return nil, &ErrSubscriptionNotFound{Subscription: id}
+	}
+	return cache, nil
+}
+
+// Subscriptions returns the sorted, lower cased ids of the subscriptions.
+func (s *SubscriptionCache) Subscriptions() []string {
+	ids := make([]string, 0, len(s.caches))
+	for id := range s.caches {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// ErrSubscriptionRefresh will be returned when refreshing the caches of
+// some subscriptions failed, with the error of each, keyed by lower
+// cased subscription id.
+type ErrSubscriptionRefresh struct {
+	Errors map[string]error
+}
+
+func (e *ErrSubscriptionRefresh) Error() string {
+	ids := make([]string, 0, len(e.Errors))
+	for id := range e.Errors {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	messages := make([]string, 0, len(ids))
+	for _, id := range ids {
+		messages = append(messages, fmt.Sprintf("subscription %s: %s", id, e.Errors[id]))
+	}
+	return fmt.Sprintf("failed to refresh %d subscriptions: %s", len(ids), strings.Join(messages, "; "))
+}
+
+// Refresh refreshes the caches of all subscriptions concurrently. A
+// failing subscription keeps its previous data and does not prevent the
+// others from refreshing: the failures of all of them are returned in an
+// ErrSubscriptionRefresh.
+func (s *SubscriptionCache) Refresh(ctx context.Context) error {
+	ids := s.Subscriptions()
+	errs := make([]error, len(ids))
+	var wg sync.WaitGroup
+	for i := range ids {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = s.caches[ids[i]].Refresh(ctx)
+		}(i)
+	}
+	wg.Wait()
+
+	failed := map[string]error{}
+	for i, id := range ids {
+		if errs[i] != nil {
+			failed[id] = errs[i]
+		}
+	}
+	if len(failed) > 0 {
+		return &ErrSubscriptionRefresh{Errors: failed}
+	}
+	return nil
+}
+
+// SubscriptionsOffering returns the subscriptions in which the virtual
+// machine size is available in the location, as reported by each
+// subscription's GetAvailableVirtualMachinesInLocation.
+func (s *SubscriptionCache) SubscriptionsOffering(ctx context.Context, size, location string) []string {
+	result := []string{}
+	for _, id := range s.Subscriptions() {
+		if len(Filter(s.caches[id].GetAvailableVirtualMachinesInLocation(ctx, location), NameFilter(size))) > 0 {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+func subscriptionKey(id string) string {
+	return strings.ToLower(strings.TrimSpace(id))
+}
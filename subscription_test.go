@@ -0,0 +1,128 @@
+package skewer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-03-01/compute" //nolint:staticcheck
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_SubscriptionCache(t *testing.T) {
+	ctx := context.Background()
+	sku := func(restricted bool) compute.ResourceSku {
+		sku := compute.ResourceSku{
+			Name:         to.StringPtr("Standard_D2s_v3"),
+			ResourceType: to.StringPtr(VirtualMachines),
+			Locations:    &[]string{"eastus"},
+			LocationInfo: &[]compute.ResourceSkuLocationInfo{{Location: to.StringPtr("eastus")}},
+		}
+		if restricted {
+			sku.Restrictions = &[]compute.ResourceSkuRestrictions{{
+				Type:   compute.Location,
+				Values: &[]string{"eastus"},
+			}}
+		}
+		return sku
+	}
+	clients := map[string]ResourceClient{}
+	for id, restricted := range map[string]bool{"AAAA": false, "bbbb": true} {
+		client, err := newSuccessfulFakeResourceClient([][]compute.ResourceSku{{sku(restricted)}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		clients[id] = client
+	}
+
+	cache, err := NewSubscriptionCache(ctx, clients, WithLocation("eastus"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"aaaa", "bbbb"}, cache.Subscriptions()); diff != "" {
+		t.Errorf("expected and actual subscriptions mismatch: %s", diff)
+	}
+
+	unrestricted, err := cache.ForSubscription("aaaa")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := len(unrestricted.GetAvailableVirtualMachines(ctx)); got != 1 {
+		t.Errorf("expected 1 available sku in subscription aaaa, got %d", got)
+	}
+	restricted, err := cache.ForSubscription("BBBB")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := len(restricted.GetAvailableVirtualMachines(ctx)); got != 0 {
+		t.Errorf("expected no available sku in subscription bbbb, got %d", got)
+	}
+
+	if diff := cmp.Diff([]string{"aaaa"}, cache.SubscriptionsOffering(ctx, "standard_d2s_v3", "East US")); diff != "" {
+		t.Errorf("expected and actual offering subscriptions mismatch: %s", diff)
+	}
+	if err := cache.Refresh(ctx); err != nil {
+		t.Errorf("expected no error refreshing, got %s", err)
+	}
+
+	var notFound *ErrSubscriptionNotFound
+	if _, err := cache.ForSubscription("cccc"); !errors.As(err, &notFound) {
+		t.Errorf("expected ErrSubscriptionNotFound, got %v", err)
+	}
+
+	clients["cccc"] = newFailingFakeResourceClient(errors.New("list failed"))
+	if _, err := NewSubscriptionCache(ctx, clients); err == nil {
+		t.Errorf("expected error when a subscription fails to list")
+	}
+	if _, err := NewSubscriptionCache(ctx, clients, WithClient(&fakeClient{})); !errors.As(err, new(*ErrClientNotNil)) {
+		t.Errorf("expected ErrClientNotNil, got %v", err)
+	}
+}
+
+func Test_SubscriptionCache_Refresh(t *testing.T) {
+	ctx := context.Background()
+	skus := []compute.ResourceSku{{Name: to.StringPtr("a"), Locations: &[]string{"eastus"}}}
+	clients := map[string]*fakeResourceClient{}
+	resourceClients := map[string]ResourceClient{}
+	for _, id := range []string{"aaaa", "bbbb", "cccc"} {
+		client, err := newSuccessfulFakeResourceClient([][]compute.ResourceSku{skus})
+		if err != nil {
+			t.Fatal(err)
+		}
+		clients[id], resourceClients[id] = client, client
+	}
+	cache, err := NewSubscriptionCache(ctx, resourceClients)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The first subscription fails, the later ones list new data.
+	clients["aaaa"].err = errors.New("list failed")
+	for _, id := range []string{"bbbb", "cccc"} {
+		iterator, err := newFakeResourceSkusResultIterator([][]compute.ResourceSku{
+			append(skus, compute.ResourceSku{Name: to.StringPtr("b"), Locations: &[]string{"eastus"}}),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		clients[id].res = iterator
+	}
+
+	var refreshErr *ErrSubscriptionRefresh
+	if err := cache.Refresh(ctx); !errors.As(err, &refreshErr) {
+		t.Fatalf("expected ErrSubscriptionRefresh, got %v", err)
+	}
+	if _, ok := refreshErr.Errors["aaaa"]; !ok || len(refreshErr.Errors) != 1 {
+		t.Errorf("expected only subscription aaaa to fail, got %v", refreshErr.Errors)
+	}
+	for id, expect := range map[string]int{"aaaa": 1, "bbbb": 2, "cccc": 2} {
+		subscription, err := cache.ForSubscription(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := subscription.Len(ctx); got != expect {
+			t.Errorf("expected %d skus in subscription %s, got %d", expect, id, got)
+		}
+	}
+}
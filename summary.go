@@ -0,0 +1,62 @@
+package skewer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// String implements fmt.Stringer with the identity of the sku, e.g.
+// "virtualMachines/Standard_D4s_v3@eastus".
+func (s *SKU) String() string {
+	location, _ := s.GetLocation()
+	return s.GetResourceType() + "/" + s.GetName() + "@" + location
+}
+
+// summaryFeatures lists the binary capabilities named by Summary.
+var summaryFeatures = []struct {
+	label     string
+	supported func(*SKU) bool
+}{
+	{"premium io", (*SKU).IsPremiumIO},
+	{"accelerated networking", (*SKU).IsAcceleratedNetworkingSupported},
+	{"ephemeral os", (*SKU).IsEphemeralOSDiskSupported},
+	{"encryption at host", (*SKU).IsEncryptionAtHostSupported},
+	{"ultra ssd", (*SKU).IsUltraSSDAvailableWithoutAvailabilityZone},
+	{"spot", (*SKU).IsSpotCapable},
+}
+
+// Summary returns a one line description of the sku for logs and
+// command line output, e.g. "Standard_D4s_v3 in eastus: 4 vCPU, 16 GiB
+// memory, zones 1,2,3, premium io, accelerated networking". Missing
+// values are left out.
+func (s *SKU) Summary() string {
+	location, _ := s.GetLocation()
+	var parts []string
+	if vcpu, err := s.VCPU(); err == nil {
+		parts = append(parts, fmt.Sprintf("%d vCPU", vcpu))
+	}
+	if memory, err := s.GetCapabilityString(MemoryGB); err == nil {
+		parts = append(parts, memory+" GiB memory")
+	}
+	if gpus, err := s.GPU(); err == nil && gpus > 0 {
+		parts = append(parts, strconv.FormatInt(gpus, ten)+" GPU")
+	}
+	if zones := s.AvailabilityZoneList(location); zones != nil {
+		parts = append(parts, "zones "+strings.Join(zones, ","))
+	}
+	for _, feature := range summaryFeatures {
+		if feature.supported(s) {
+			parts = append(parts, feature.label)
+		}
+	}
+
+	summary := s.GetName()
+	if location != "" {
+		summary += " in " + location
+	}
+	if len(parts) > 0 {
+		summary += ": " + strings.Join(parts, ", ")
+	}
+	return summary
+}
@@ -0,0 +1,36 @@
+package skewer
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_SKU_Summary(t *testing.T) {
+	dataWrapper, err := newDataWrapper("./testdata/eastus.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache, err := NewCache(context.Background(), WithClient(&fakeClient{skus: dataWrapper.Value}), WithLocation("eastus"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sku, err := cache.Get(context.Background(), "Standard_D4s_v3", VirtualMachines, "eastus")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff("virtualMachines/Standard_D4s_v3@eastus", fmt.Sprint(&sku)); diff != "" {
+		t.Error(diff)
+	}
+	expect := "Standard_D4s_v3 in eastus: 4 vCPU, 16 GiB memory, zones 1,2,3, premium io, accelerated networking, " +
+		"ephemeral os, encryption at host, spot"
+	if diff := cmp.Diff(expect, sku.Summary()); diff != "" {
+		t.Error(diff)
+	}
+	if diff := cmp.Diff("", (&SKU{}).Summary()); diff != "" {
+		t.Error(diff)
+	}
+}
@@ -0,0 +1,144 @@
+package skewer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-03-01/compute" //nolint:staticcheck
+)
+
+// VMRequest describes a desired virtual machine, for example decoded
+// from an admission request, to validate against a cache.
+type VMRequest struct {
+	// Size is the sku name, e.g. "Standard_D4s_v3".
+	Size string `json:"size"`
+	// Location defaults to the cache location when empty.
+	Location string `json:"location,omitempty"`
+	// Zone is the availability zone, empty for regional deployments.
+	Zone string `json:"zone,omitempty"`
+	// DiskTypes are the storage account types of all attached disks.
+	DiskTypes []compute.StorageAccountTypes `json:"diskTypes,omitempty"`
+	// DataDisks is the number of data disks to attach.
+	DataDisks int64 `json:"dataDisks,omitempty"`
+	// DataDiskCaching is the host caching mode of the data disks.
+	DataDiskCaching compute.CachingTypes `json:"dataDiskCaching,omitempty"`
+	// HyperVGeneration is the generation of the image, if known.
+	HyperVGeneration HyperVGeneration `json:"hyperVGeneration,omitempty"`
+
+	EphemeralOSDisk       bool `json:"ephemeralOSDisk,omitempty"`
+	EncryptionAtHost      bool `json:"encryptionAtHost,omitempty"`
+	AcceleratedNetworking bool `json:"acceleratedNetworking,omitempty"`
+}
+
+// Violation describes one way a VMRequest conflicts with the catalog.
+type Violation struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func (v Violation) String() string {
+	return v.Field + ": " + v.Message
+}
+
+// Validate returns every violation of the request against the skus in
+// the cache, or no violations when the virtual machine can be deployed
+// as described. It is suitable for admission webhooks.
+func (c *Cache) Validate(ctx context.Context, req VMRequest) []Violation { //nolint:gocyclo
+	location := req.Location
+	if location == "" {
+		location = c.config.location
+	}
+
+	sku, err := c.Get(ctx, req.Size, VirtualMachines, location)
+	if err != nil {
+		return []Violation{{Field: "size", Message: err.Error()}}
+	}
+
+	var violations []Violation
+	add := func(field, format string, args ...interface{}) {
+		violations = append(violations, Violation{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if !sku.IsAvailableWithZones(location) {
+		add("size", "sku %s is restricted in location %s", req.Size, location)
+	}
+	if req.Zone != "" && !sku.AvailabilityZones(location)[req.Zone] {
+		add("zone", "sku %s is not available in zone %s of location %s", req.Size, req.Zone, location)
+	}
+	if req.HyperVGeneration != "" && !sku.IsHyperVGenerationSupported(req.HyperVGeneration) {
+		add("hyperVGeneration", "sku %s does not support hyper-v generation %s", req.Size, req.HyperVGeneration)
+	}
+	if req.DataDisks > 0 {
+		if ok, err := sku.HasCapabilityWithMinCapacity(CapabilityMaxDataDiskCount, req.DataDisks); err != nil || !ok {
+			add("dataDisks", "sku %s does not support %d data disks", req.Size, req.DataDisks)
+		}
+	}
+	if req.DataDiskCaching != "" {
+		if err := sku.ValidateDataDiskCaching(req.DataDiskCaching, "", 0); err != nil {
+			add("dataDiskCaching", "%s", err)
+		}
+	}
+	for _, diskType := range req.DiskTypes {
+		if !sku.supportsStorageAccountType(diskType, req.Zone) {
+			add("diskTypes", "sku %s does not support disk type %s", req.Size, diskType)
+		}
+	}
+	if req.EphemeralOSDisk && !sku.IsEphemeralOSDiskSupported() {
+		add("ephemeralOSDisk", "sku %s does not support ephemeral os disks", req.Size)
+	}
+	if req.EncryptionAtHost && !sku.IsEncryptionAtHostSupported() {
+		add("encryptionAtHost", "sku %s does not support encryption at host", req.Size)
+	}
+	if req.AcceleratedNetworking && !sku.IsAcceleratedNetworkingSupported() {
+		add("acceleratedNetworking", "sku %s does not support accelerated networking", req.Size)
+	}
+
+	return violations
+}
+
+// supportsStorageAccountType returns true when disks of the type can be
+// attached to the VM size, in the zone when one is provided.
+func (s *SKU) supportsStorageAccountType(diskType compute.StorageAccountTypes, zone string) bool {
+	switch {
+	case strings.EqualFold(string(diskType), string(compute.StorageAccountTypesUltraSSDLRS)):
+		if zone != "" {
+			return s.IsUltraSSDAvailableInAvailabilityZone(zone)
+		}
+		return s.IsUltraSSDAvailableWithoutAvailabilityZone()
+	case strings.EqualFold(string(diskType), string(compute.StorageAccountTypesPremiumV2LRS)):
+		// Premium SSD v2 disks can only be attached to zonal vms.
+		return zone != "" && s.IsPremiumIO()
+	case strings.EqualFold(string(diskType), string(compute.StorageAccountTypesPremiumLRS)),
+		strings.EqualFold(string(diskType), string(compute.StorageAccountTypesPremiumZRS)):
+		return s.IsPremiumIO()
+	default:
+		return true
+	}
+}
+
+// diskTypes lists the managed disk types in order of performance.
+var diskTypes = []compute.StorageAccountTypes{
+	compute.StorageAccountTypesStandardLRS,
+	compute.StorageAccountTypesStandardSSDLRS,
+	compute.StorageAccountTypesStandardSSDZRS,
+	compute.StorageAccountTypesPremiumLRS,
+	compute.StorageAccountTypesPremiumZRS,
+	compute.StorageAccountTypesPremiumV2LRS,
+	compute.StorageAccountTypesUltraSSDLRS,
+}
+
+// SupportedDiskTypes returns the managed disk types which can be
+// attached to the VM size, in order of performance. Premium disks
+// require PremiumIO, Premium SSD v2 disks additionally require a zone,
+// and ultra disks require UltraSSDAvailable in the zone, or for the
+// location when zone is empty.
+func (s *SKU) SupportedDiskTypes(zone string) []compute.StorageAccountTypes {
+	var result []compute.StorageAccountTypes
+	for _, diskType := range diskTypes {
+		if s.supportsStorageAccountType(diskType, zone) {
+			result = append(result, diskType)
+		}
+	}
+	return result
+}
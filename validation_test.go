@@ -0,0 +1,148 @@
+package skewer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-03-01/compute" //nolint:staticcheck
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_Cache_Validate(t *testing.T) {
+	dataWrapper, err := newDataWrapper("./testdata/eastus.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache, err := NewStaticCache(Wrap(dataWrapper.Value), WithLocation("eastus"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]struct {
+		req    VMRequest
+		expect []string
+	}{
+		"should accept supported request": {
+			req: VMRequest{
+				Size:             "Standard_D4s_v3",
+				Zone:             "1",
+				DiskTypes:        []compute.StorageAccountTypes{compute.StorageAccountTypesPremiumLRS},
+				DataDisks:        8,
+				DataDiskCaching:  compute.CachingTypesReadOnly,
+				HyperVGeneration: HyperVGenerationV2,
+				EphemeralOSDisk:  true,
+				EncryptionAtHost: true,
+			},
+		},
+		"should reject unknown size": {
+			req:    VMRequest{Size: "Standard_Foo"},
+			expect: []string{"size"},
+		},
+		"should reject every unsupported field": {
+			req: VMRequest{
+				Size:                  "Standard_NV6",
+				Zone:                  "1",
+				DiskTypes:             []compute.StorageAccountTypes{compute.StorageAccountTypesPremiumLRS},
+				DataDisks:             32,
+				DataDiskCaching:       compute.CachingTypesReadWrite,
+				HyperVGeneration:      HyperVGenerationV2,
+				EphemeralOSDisk:       true,
+				EncryptionAtHost:      true,
+				AcceleratedNetworking: true,
+			},
+			expect: []string{
+				"size",
+				"zone",
+				"hyperVGeneration",
+				"dataDisks",
+				"dataDiskCaching",
+				"diskTypes",
+				"ephemeralOSDisk",
+				"encryptionAtHost",
+				"acceleratedNetworking",
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			var fields []string
+			for _, violation := range cache.Validate(context.Background(), tc.req) {
+				fields = append(fields, violation.Field)
+			}
+			if diff := cmp.Diff(tc.expect, fields); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func Test_SKU_SupportedDiskTypes(t *testing.T) {
+	premium := func(ultra bool) *SKU {
+		value := "False"
+		if ultra {
+			value = "True"
+		}
+		return &SKU{
+			Capabilities: &[]compute.ResourceSkuCapabilities{
+				{Name: to.StringPtr(CapabilityPremiumIO), Value: to.StringPtr("True")},
+			},
+			LocationInfo: &[]compute.ResourceSkuLocationInfo{
+				{
+					Location: to.StringPtr("eastus"),
+					ZoneDetails: &[]compute.ResourceSkuZoneDetails{
+						{
+							Name:         &[]string{"1"},
+							Capabilities: &[]compute.ResourceSkuCapabilities{{Name: to.StringPtr(UltraSSDAvailable), Value: &value}},
+						},
+					},
+				},
+			},
+		}
+	}
+	standard := []compute.StorageAccountTypes{
+		compute.StorageAccountTypesStandardLRS,
+		compute.StorageAccountTypesStandardSSDLRS,
+		compute.StorageAccountTypesStandardSSDZRS,
+	}
+	withPremium := append(append([]compute.StorageAccountTypes{}, standard...),
+		compute.StorageAccountTypesPremiumLRS, compute.StorageAccountTypesPremiumZRS)
+
+	cases := map[string]struct {
+		sku    *SKU
+		zone   string
+		expect []compute.StorageAccountTypes
+	}{
+		"should only support standard disks without premium io": {
+			sku:    &SKU{},
+			zone:   "1",
+			expect: standard,
+		},
+		"should not support premium v2 disks without zone": {
+			sku:    premium(false),
+			expect: withPremium,
+		},
+		"should support premium v2 disks in zone": {
+			sku:    premium(false),
+			zone:   "1",
+			expect: append(append([]compute.StorageAccountTypes{}, withPremium...), compute.StorageAccountTypesPremiumV2LRS),
+		},
+		"should support ultra disks in zone with ultra ssd": {
+			sku:  premium(true),
+			zone: "1",
+			expect: append(append([]compute.StorageAccountTypes{}, withPremium...),
+				compute.StorageAccountTypesPremiumV2LRS, compute.StorageAccountTypesUltraSSDLRS),
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.expect, tc.sku.SupportedDiskTypes(tc.zone)); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"regexp"
 	"strconv"
+	"strings"
 )
 
 // This file adds support for more capabilities based on VM naming conventions that includes vmsize parsing.
@@ -129,3 +130,164 @@ func getVMSize(vmSizeName string) (*VMSizeType, error) {
 
 	return &vmSize, nil
 }
+
+// hasAdditiveFeature returns true when the vm size name carries the
+// provided additive feature letter, e.g. 's' for premium storage.
+func (vm *VMSizeType) hasAdditiveFeature(feature rune) bool {
+	for _, candidate := range vm.additiveFeatures {
+		if candidate == feature {
+			return true
+		}
+	}
+	return false
+}
+
+// seriesKey identifies the series of the vm size by family, sub-family
+// and version, ignoring additive features, e.g. "HB_v3" for
+// "HB120rs_v3".
+func (vm *VMSizeType) seriesKey() string {
+	key := vm.family
+	if vm.subfamily != nil {
+		key += *vm.subfamily
+	}
+	if vm.version != "" {
+		key += "_" + strings.ToLower(vm.version)
+	}
+	return key
+}
+
+// ParsedName is a sku name decomposed according to the VM naming
+// conventions, e.g. tier "Standard" and size "D8ads_v5".
+type ParsedName struct {
+	Tier string
+	*VMSizeType
+}
+
+// ParseName decomposes a VM sku name such as "Standard_D8ads_v5" into
+// its tier and size. Names without a tier, such as "D8ads_v5", parse
+// with an empty tier.
+func ParseName(name string) (ParsedName, error) {
+	tier, size := "", name
+	if prefix, rest, ok := strings.Cut(name, "_"); ok && isTier(prefix) {
+		tier, size = prefix, rest
+	}
+	vmSize, err := getVMSize(size)
+	if err != nil {
+		return ParsedName{}, err
+	}
+	return ParsedName{Tier: tier, VMSizeType: vmSize}, nil
+}
+
+// isTier returns true for the tiers prefixing VM sku names.
+func isTier(prefix string) bool {
+	return strings.EqualFold(prefix, "Standard") || strings.EqualFold(prefix, "Basic")
+}
+
+// Family returns the family letter, e.g. "D" for "D8ads_v5".
+func (vm *VMSizeType) Family() string {
+	return vm.family
+}
+
+// Subfamily returns the sub-family letter, e.g. "C" for "NC6", or the
+// empty string.
+func (vm *VMSizeType) Subfamily() string {
+	if vm.subfamily == nil {
+		return ""
+	}
+	return *vm.subfamily
+}
+
+// VCPUs returns the number of vCPUs the size is named for, e.g. 8 for
+// both "D8ads_v5" and the constrained "E8-4s_v3".
+func (vm *VMSizeType) VCPUs() int64 {
+	cpus, _ := strconv.ParseInt(vm.cpus, ten, sixtyFour)
+	return cpus
+}
+
+// ConstrainedVCPUs returns the number of vCPUs available on constrained
+// core sizes, e.g. 4 for "E8-4s_v3". The boolean is false for sizes
+// which are not constrained.
+func (vm *VMSizeType) ConstrainedVCPUs() (int64, bool) {
+	if vm.cpusConstrained == nil {
+		return 0, false
+	}
+	cpus, err := strconv.ParseInt(*vm.cpusConstrained, ten, sixtyFour)
+	return cpus, err == nil
+}
+
+// AdditiveFeatures returns the additive feature letters, e.g. "ads" for
+// "D8ads_v5".
+func (vm *VMSizeType) AdditiveFeatures() string {
+	return string(vm.additiveFeatures)
+}
+
+// HasAdditiveFeature returns true when the name carries the additive
+// feature letter, which is case sensitive.
+func (vm *VMSizeType) HasAdditiveFeature(feature rune) bool {
+	return vm.hasAdditiveFeature(feature)
+}
+
+// IsAMD returns true for AMD based sizes, named with 'a'.
+func (vm *VMSizeType) IsAMD() bool {
+	return vm.hasAdditiveFeature('a')
+}
+
+// HasLocalTempDisk returns true for sizes with a local temporary disk,
+// named with 'd'.
+func (vm *VMSizeType) HasLocalTempDisk() bool {
+	return vm.hasAdditiveFeature('d')
+}
+
+// IsPremiumStorageCapable returns true for sizes supporting premium
+// storage, named with 's'.
+func (vm *VMSizeType) IsPremiumStorageCapable() bool {
+	return vm.hasAdditiveFeature('s')
+}
+
+// IsLowMemory returns true for sizes with less memory per vCPU than
+// their family, named with 'l'.
+func (vm *VMSizeType) IsLowMemory() bool {
+	return vm.hasAdditiveFeature('l')
+}
+
+// IsArm returns true for Arm based sizes, named with 'p'.
+func (vm *VMSizeType) IsArm() bool {
+	return vm.hasAdditiveFeature('p')
+}
+
+// IsMemoryIntensive returns true for sizes with more memory per vCPU
+// than their family, named with 'm'.
+func (vm *VMSizeType) IsMemoryIntensive() bool {
+	return vm.hasAdditiveFeature('m')
+}
+
+// AcceleratorType returns the accelerator type, e.g. "T4" for
+// "NC4as_T4_v3", or the empty string.
+func (vm *VMSizeType) AcceleratorType() string {
+	if vm.acceleratorType == nil {
+		return ""
+	}
+	return *vm.acceleratorType
+}
+
+// IsConfidentialChild returns true for sizes named with "_cc_", which
+// support confidential child containers on AKS.
+func (vm *VMSizeType) IsConfidentialChild() bool {
+	return vm.confidentialChildCapability
+}
+
+// Version returns the version, e.g. "v5", or the empty string for
+// first generation sizes.
+func (vm *VMSizeType) Version() string {
+	return vm.version
+}
+
+// IsPromo returns true for promotional sizes named with "_Promo".
+func (vm *VMSizeType) IsPromo() bool {
+	return vm.promoVersion
+}
+
+// Series returns the series, e.g. "Dads_v5" for "D8ads_v5".
+func (vm *VMSizeType) Series() string {
+	return vm.series
+}
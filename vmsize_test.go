@@ -197,3 +197,55 @@ func Test_getVMsize(t *testing.T) {
 		a.Equal(test.expectedVM.promoVersion, vmSize.promoVersion)
 	}
 }
+
+func Test_ParseName(t *testing.T) {
+	a := assert.New(t)
+
+	name, err := ParseName("Standard_D8ads_v5")
+	a.NoError(err)
+	a.Equal("Standard", name.Tier)
+	a.Equal("D", name.Family())
+	a.Equal("", name.Subfamily())
+	a.Equal(int64(8), name.VCPUs())
+	a.Equal("ads", name.AdditiveFeatures())
+	a.True(name.IsAMD())
+	a.True(name.HasLocalTempDisk())
+	a.True(name.IsPremiumStorageCapable())
+	a.False(name.IsArm())
+	a.False(name.IsLowMemory())
+	a.False(name.IsMemoryIntensive())
+	a.Equal("v5", name.Version())
+	a.Equal("Dads_v5", name.Series())
+	_, constrained := name.ConstrainedVCPUs()
+	a.False(constrained)
+
+	name, err = ParseName("Standard_E8-4s_v3")
+	a.NoError(err)
+	cpus, constrained := name.ConstrainedVCPUs()
+	a.True(constrained)
+	a.Equal(int64(4), cpus)
+	a.Equal(int64(8), name.VCPUs())
+
+	name, err = ParseName("NC4as_T4_v3")
+	a.NoError(err)
+	a.Equal("", name.Tier)
+	a.Equal("C", name.Subfamily())
+	a.Equal("T4", name.AcceleratorType())
+
+	name, err = ParseName("Standard_D13_v2_Promo")
+	a.NoError(err)
+	a.True(name.IsPromo())
+	a.False(name.IsConfidentialChild())
+
+	name, err = ParseName("Standard_DC4as_cc_v5")
+	a.NoError(err)
+	a.True(name.IsConfidentialChild())
+
+	name, err = ParseName("Standard_D2pls_v5")
+	a.NoError(err)
+	a.True(name.IsArm())
+	a.True(name.IsLowMemory())
+
+	_, err = ParseName("Standard_not a size")
+	a.Error(err)
+}